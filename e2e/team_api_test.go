@@ -10,7 +10,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-const baseURL = "http://localhost:8080"
+const (
+	baseURL    = "http://localhost:8080"
+	adminToken = "admin-token"
+)
 
 type User struct {
 	UserID   string `json:"user_id"`
@@ -34,7 +37,12 @@ func postJSON(t *testing.T, url string, payload interface{}) (*http.Response, []
 	body, err := json.Marshal(payload)
 	assert.NoError(t, err)
 
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
 	assert.NoError(t, err)
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -45,7 +53,11 @@ func postJSON(t *testing.T, url string, payload interface{}) (*http.Response, []
 }
 
 func getJSON(t *testing.T, url string) (*http.Response, []byte) {
-	resp, err := http.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
 	assert.NoError(t, err)
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+
 	"avitotest/internal/container"
 )
 
@@ -9,6 +11,22 @@ func Run() {
 	if err != nil {
 		panic(err)
 	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go ctn.WebhookDispatcher.Run(ctx)
+	go ctn.CodeHostSyncer.Run(ctx)
+	go ctn.SLAScheduler.Run(ctx)
+	go ctn.ChatNotifier.Run(ctx)
+	go ctn.StreamHub.Run(ctx)
+	if ctn.ReminderScheduler != nil {
+		go ctn.ReminderScheduler.Run(ctx)
+	}
+	if ctn.EmailNotifier != nil {
+		go ctn.EmailNotifier.Run(ctx)
+	}
+
 	e := ctn.Router.SetupRoutes()
 	if err := e.Start(":" + ctn.Config.ServerPort); err != nil {
 		panic(err)
@@ -0,0 +1,97 @@
+package codehost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type GitHubConfig struct {
+	BaseURL     string
+	Token       string
+	Timeout     time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration
+}
+
+type GitHubClient struct {
+	cfg    GitHubConfig
+	client *http.Client
+}
+
+func NewGitHubClient(cfg GitHubConfig) domain.CodeHostClient {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &GitHubClient{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (c *GitHubClient) RequestReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	return c.requestedReviewers(ctx, http.MethodPost, repository, number, logins)
+}
+
+func (c *GitHubClient) RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	return c.requestedReviewers(ctx, http.MethodDelete, repository, number, logins)
+}
+
+func (c *GitHubClient) requestedReviewers(ctx context.Context, method, repository string, number int, logins []string) error {
+	body, err := json.Marshal(map[string][]string{"reviewers": logins})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviewers: %w", err)
+	}
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.cfg.BaseURL, repository, number)
+
+	var lastErr error
+	delay := c.cfg.BaseBackoff
+	for attempt := 1; attempt <= c.cfg.MaxAttempts; attempt++ {
+		retryable, err := c.do(ctx, method, url, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable || attempt == c.cfg.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return lastErr
+}
+
+func (c *GitHubClient) do(ctx context.Context, method, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("github request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("github responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
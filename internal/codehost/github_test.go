@@ -0,0 +1,103 @@
+package codehost_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avitotest/internal/codehost"
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	Method        string
+	Path          string
+	Authorization string
+	Reviewers     []string
+}
+
+func newFakeGitHub(t *testing.T, failures int32, failStatus int) (*httptest.Server, *[]recordedRequest, *int32) {
+	var calls int32
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Reviewers []string `json:"reviewers"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, recordedRequest{
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			Authorization: r.Header.Get("Authorization"),
+			Reviewers:     body.Reviewers,
+		})
+
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(failStatus)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &calls
+}
+
+func newClient(baseURL string) domain.CodeHostClient {
+	return codehost.NewGitHubClient(codehost.GitHubConfig{
+		BaseURL:     baseURL,
+		Token:       "gh-token",
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+	})
+}
+
+func TestGitHubClientRequestsAndRemovesReviewers(t *testing.T) {
+	server, requests, _ := newFakeGitHub(t, 0, 0)
+	client := newClient(server.URL)
+
+	require.NoError(t, client.RequestReviewers(context.Background(), "acme/api", 12, []string{"bob"}))
+	require.NoError(t, client.RemoveReviewers(context.Background(), "acme/api", 12, []string{"carol"}))
+
+	require.Len(t, *requests, 2)
+	assert.Equal(t, recordedRequest{
+		Method:        http.MethodPost,
+		Path:          "/repos/acme/api/pulls/12/requested_reviewers",
+		Authorization: "Bearer gh-token",
+		Reviewers:     []string{"bob"},
+	}, (*requests)[0])
+	assert.Equal(t, http.MethodDelete, (*requests)[1].Method)
+	assert.Equal(t, []string{"carol"}, (*requests)[1].Reviewers)
+}
+
+func TestGitHubClientRetriesServerErrors(t *testing.T) {
+	server, _, calls := newFakeGitHub(t, 2, http.StatusBadGateway)
+	client := newClient(server.URL)
+
+	require.NoError(t, client.RequestReviewers(context.Background(), "acme/api", 1, []string{"bob"}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestGitHubClientGivesUpAfterMaxAttempts(t *testing.T) {
+	server, _, calls := newFakeGitHub(t, 10, http.StatusServiceUnavailable)
+	client := newClient(server.URL)
+
+	err := client.RequestReviewers(context.Background(), "acme/api", 1, []string{"bob"})
+	assert.ErrorContains(t, err, "503")
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestGitHubClientDoesNotRetryClientErrors(t *testing.T) {
+	server, _, calls := newFakeGitHub(t, 10, http.StatusUnprocessableEntity)
+	client := newClient(server.URL)
+
+	err := client.RequestReviewers(context.Background(), "acme/api", 1, []string{"bob"})
+	assert.ErrorContains(t, err, "422")
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
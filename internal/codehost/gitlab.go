@@ -0,0 +1,64 @@
+package codehost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type GitLabConfig struct {
+	BaseURL string
+	Token   string
+	Timeout time.Duration
+}
+
+type GitLabClient struct {
+	cfg    GitLabConfig
+	client *http.Client
+}
+
+func NewGitLabClient(cfg GitLabConfig) domain.CodeHostUserDirectory {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &GitLabClient{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (c *GitLabClient) GetLogin(ctx context.Context, externalID int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/users/%d", c.cfg.BaseURL, externalID), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build gitlab request: %w", err)
+	}
+	if c.cfg.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.cfg.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gitlab request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, fmt.Sprintf("gitlab user %d not found", externalID))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("gitlab responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return "", fmt.Errorf("failed to decode gitlab user: %w", err)
+	}
+	return user.Username, nil
+}
@@ -0,0 +1,41 @@
+package codehost_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitotest/internal/codehost"
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabClientResolvesLogin(t *testing.T) {
+	var path, token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, token = r.URL.Path, r.Header.Get("PRIVATE-TOKEN")
+		if r.URL.Path != "/api/v4/users/42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":42,"username":"Alice"}`))
+	}))
+	defer server.Close()
+
+	client := codehost.NewGitLabClient(codehost.GitLabConfig{BaseURL: server.URL + "/api/v4/", Token: "gl-token", Timeout: time.Second})
+
+	login, err := client.GetLogin(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", login)
+	assert.Equal(t, "/api/v4/users/42", path)
+	assert.Equal(t, "gl-token", token)
+
+	_, err = client.GetLogin(context.Background(), 7)
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNotFound, domainErr.Code)
+}
@@ -0,0 +1,237 @@
+package codehost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type SyncerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+type Syncer struct {
+	integrationRepo domain.IntegrationRepository
+	outboxRepo      domain.OutboxRepository
+	syncRepo        domain.CodeHostSyncRepository
+	clients         map[domain.CodeHostProvider]domain.CodeHostClient
+	cfg             SyncerConfig
+	logger          *slog.Logger
+}
+
+func NewSyncer(
+	integrationRepo domain.IntegrationRepository,
+	outboxRepo domain.OutboxRepository,
+	syncRepo domain.CodeHostSyncRepository,
+	clients map[domain.CodeHostProvider]domain.CodeHostClient,
+	cfg SyncerConfig,
+	logger *slog.Logger,
+) *Syncer {
+	return &Syncer{
+		integrationRepo: integrationRepo,
+		outboxRepo:      outboxRepo,
+		syncRepo:        syncRepo,
+		clients:         clients,
+		cfg:             cfg,
+		logger:          logger,
+	}
+}
+
+func (s *Syncer) Enqueue(ctx context.Context, event *domain.Event) error {
+	if len(s.clients) == 0 {
+		return nil
+	}
+	switch event.EventType {
+	case domain.EventReviewerAssigned, domain.EventReviewerReassigned, domain.EventReviewerUnassigned:
+		return s.syncRepo.CreateJob(ctx, event.EventID)
+	}
+	return nil
+}
+
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SyncBatch(ctx); err != nil {
+				s.logger.Error("code host sync failed", "error", err)
+			}
+		}
+	}
+}
+
+func (s *Syncer) SyncBatch(ctx context.Context) error {
+	tasks, err := s.syncRepo.ClaimDueJobs(ctx, s.cfg.BatchSize, s.cfg.PollInterval+s.cfg.MaxBackoff)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		ctx := domain.WithTenant(ctx, task.Event.TenantID)
+		link, syncErr := s.sync(ctx, &task.Event)
+		if syncErr == nil {
+			if err := s.syncRepo.MarkJobSynced(ctx, task.Event.EventID); err != nil {
+				s.logger.Error("failed to mark code host sync job synced", "event_id", task.Event.EventID, "error", err)
+			}
+			continue
+		}
+		s.fail(ctx, task, link, syncErr)
+	}
+	return nil
+}
+
+func (s *Syncer) fail(ctx context.Context, task *domain.CodeHostSyncTask, link *domain.ExternalPullRequest, syncErr *syncError) {
+	attempts := task.Attempts + 1
+	dead := attempts >= s.cfg.MaxAttempts || isNotFound(syncErr.err)
+	if err := s.syncRepo.MarkJobFailed(ctx, task.Event.EventID, syncErr.Error(), time.Now().Add(s.backoff(attempts)), dead); err != nil {
+		s.logger.Error("failed to mark code host sync job failed", "event_id", task.Event.EventID, "error", err)
+	}
+	if !dead {
+		s.logger.Warn("code host reviewer sync failed, will retry",
+			"event_id", task.Event.EventID,
+			"attempts", attempts,
+			"error", syncErr,
+		)
+		return
+	}
+	s.report(ctx, link, syncErr.operation, syncErr.err)
+}
+
+func (s *Syncer) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.cfg.MaxBackoff {
+		delay = s.cfg.MaxBackoff
+	}
+	return delay
+}
+
+type syncError struct {
+	operation string
+	err       error
+}
+
+func (e *syncError) Error() string {
+	return e.operation + ": " + e.err.Error()
+}
+
+func (s *Syncer) sync(ctx context.Context, event *domain.Event) (*domain.ExternalPullRequest, *syncError) {
+	var prID, removedUserID, addedUserID string
+	switch event.EventType {
+	case domain.EventReviewerAssigned:
+		var payload domain.ReviewerAssignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			s.logger.Error("failed to decode event payload", "event_id", event.EventID, "error", err)
+			return nil, nil
+		}
+		prID, addedUserID = payload.PullRequestID, payload.ReviewerID
+	case domain.EventReviewerReassigned:
+		var payload domain.ReviewerReassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			s.logger.Error("failed to decode event payload", "event_id", event.EventID, "error", err)
+			return nil, nil
+		}
+		prID, removedUserID, addedUserID = payload.PullRequestID, payload.OldReviewerID, payload.NewReviewerID
+	case domain.EventReviewerUnassigned:
+		var payload domain.ReviewerUnassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			s.logger.Error("failed to decode event payload", "event_id", event.EventID, "error", err)
+			return nil, nil
+		}
+		prID, removedUserID = payload.PullRequestID, payload.ReviewerID
+	default:
+		return nil, nil
+	}
+	return s.apply(ctx, prID, removedUserID, addedUserID)
+}
+
+func (s *Syncer) apply(ctx context.Context, prID, removedUserID, addedUserID string) (*domain.ExternalPullRequest, *syncError) {
+	link, err := s.integrationRepo.GetPullRequestLinkByID(ctx, prID)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &syncError{operation: "resolve_pull_request", err: err}
+	}
+
+	client, ok := s.clients[link.Provider]
+	if !ok {
+		return link, nil
+	}
+
+	if removedUserID != "" {
+		if err := s.call(ctx, link, removedUserID, client.RemoveReviewers); err != nil {
+			return link, &syncError{operation: "remove_reviewer", err: err}
+		}
+	}
+	if addedUserID != "" {
+		if err := s.call(ctx, link, addedUserID, client.RequestReviewers); err != nil {
+			return link, &syncError{operation: "request_reviewer", err: err}
+		}
+	}
+	return link, nil
+}
+
+func (s *Syncer) call(
+	ctx context.Context,
+	link *domain.ExternalPullRequest,
+	userID string,
+	fn func(ctx context.Context, repository string, number int, logins []string) error,
+) error {
+	login, err := s.integrationRepo.GetLoginByUserID(ctx, link.Provider, userID)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, link.Repository, link.Number, []string{login}); err != nil {
+		return fmt.Errorf("%s %s#%d: %w", link.Provider, link.Repository, link.Number, err)
+	}
+	return nil
+}
+
+func (s *Syncer) report(ctx context.Context, link *domain.ExternalPullRequest, operation string, syncErr error) {
+	if link == nil {
+		s.logger.Error("code host reviewer sync failed", "operation", operation, "error", syncErr)
+		return
+	}
+	s.logger.Error("code host reviewer sync failed",
+		"pull_request_id", link.PullRequestID,
+		"provider", link.Provider,
+		"operation", operation,
+		"error", syncErr,
+	)
+
+	event, err := domain.NewEvent(domain.EventCodeHostSyncFailed, link.PullRequestID, domain.CodeHostSyncFailedPayload{
+		PullRequestID: link.PullRequestID,
+		Provider:      link.Provider,
+		Repository:    link.Repository,
+		Number:        link.Number,
+		Operation:     operation,
+		Error:         syncErr.Error(),
+	})
+	if err == nil {
+		err = s.outboxRepo.Append(ctx, event)
+	}
+	if err != nil {
+		s.logger.Error("failed to record code host sync failure", "pull_request_id", link.PullRequestID, "error", err)
+	}
+}
+
+func isNotFound(err error) bool {
+	var domainErr *domain.DomainError
+	return errors.As(err, &domainErr) && domainErr.Code == domain.ErrorCodeNotFound
+}
@@ -0,0 +1,242 @@
+package codehost_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"avitotest/internal/codehost"
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIntegrationRepo struct {
+	domain.IntegrationRepository
+	links  map[string]*domain.ExternalPullRequest
+	logins map[string]string
+}
+
+func (r *fakeIntegrationRepo) GetPullRequestLinkByID(ctx context.Context, prID string) (*domain.ExternalPullRequest, error) {
+	link, ok := r.links[prID]
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pull request link not found")
+	}
+	return link, nil
+}
+
+func (r *fakeIntegrationRepo) GetLoginByUserID(ctx context.Context, provider domain.CodeHostProvider, userID string) (string, error) {
+	login, ok := r.logins[userID]
+	if !ok {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, "identity not found")
+	}
+	return login, nil
+}
+
+type fakeSyncOutboxRepo struct {
+	domain.OutboxRepository
+	appended []*domain.Event
+}
+
+func (r *fakeSyncOutboxRepo) Append(ctx context.Context, events ...*domain.Event) error {
+	r.appended = append(r.appended, events...)
+	return nil
+}
+
+type failedJob struct {
+	eventID int64
+	tenant  string
+	dead    bool
+	delay   time.Duration
+}
+
+type fakeSyncRepo struct {
+	mu      sync.Mutex
+	created []int64
+	tasks   []*domain.CodeHostSyncTask
+	synced  []int64
+	failed  []failedJob
+}
+
+func (r *fakeSyncRepo) CreateJob(ctx context.Context, eventID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created = append(r.created, eventID)
+	return nil
+}
+
+func (r *fakeSyncRepo) ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]*domain.CodeHostSyncTask, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := r.tasks
+	r.tasks = nil
+	return tasks, nil
+}
+
+func (r *fakeSyncRepo) MarkJobSynced(ctx context.Context, eventID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.synced = append(r.synced, eventID)
+	return nil
+}
+
+func (r *fakeSyncRepo) MarkJobFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed = append(r.failed, failedJob{eventID, domain.TenantFromContext(ctx), dead, time.Until(nextAttemptAt)})
+	return nil
+}
+
+type clientCall struct {
+	op         string
+	repository string
+	number     int
+	logins     []string
+}
+
+type fakeClient struct {
+	calls []clientCall
+	err   error
+}
+
+func (c *fakeClient) RequestReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	c.calls = append(c.calls, clientCall{"request", repository, number, logins})
+	return c.err
+}
+
+func (c *fakeClient) RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error {
+	c.calls = append(c.calls, clientCall{"remove", repository, number, logins})
+	return c.err
+}
+
+type syncerFixture struct {
+	integrationRepo *fakeIntegrationRepo
+	outboxRepo      *fakeSyncOutboxRepo
+	syncRepo        *fakeSyncRepo
+	client          *fakeClient
+	syncer          *codehost.Syncer
+}
+
+func newSyncerFixture(clients bool) *syncerFixture {
+	f := &syncerFixture{
+		integrationRepo: &fakeIntegrationRepo{
+			links: map[string]*domain.ExternalPullRequest{
+				"pr1": {PullRequestID: "pr1", Provider: domain.ProviderGitHub, Repository: "acme/api", Number: 12},
+			},
+			logins: map[string]string{"u2": "bob", "u3": "carol"},
+		},
+		outboxRepo: &fakeSyncOutboxRepo{},
+		syncRepo:   &fakeSyncRepo{},
+		client:     &fakeClient{},
+	}
+	clientMap := map[domain.CodeHostProvider]domain.CodeHostClient{}
+	if clients {
+		clientMap[domain.ProviderGitHub] = f.client
+	}
+	f.syncer = codehost.NewSyncer(f.integrationRepo, f.outboxRepo, f.syncRepo, clientMap, codehost.SyncerConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   time.Minute,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return f
+}
+
+func syncEvent(t *testing.T, eventID int64, eventType domain.EventType, payload interface{}) domain.Event {
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return domain.Event{EventID: eventID, TenantID: "t1", EventType: eventType, AggregateID: "pr1", Payload: body}
+}
+
+func TestSyncerEnqueuesOnlyReviewerEvents(t *testing.T) {
+	f := newSyncerFixture(true)
+	ctx := context.Background()
+
+	for i, eventType := range []domain.EventType{
+		domain.EventReviewerAssigned,
+		domain.EventPRMerged,
+		domain.EventReviewerReassigned,
+		domain.EventPRCreated,
+		domain.EventReviewerUnassigned,
+	} {
+		require.NoError(t, f.syncer.Enqueue(ctx, &domain.Event{EventID: int64(i + 1), EventType: eventType}))
+	}
+	assert.Equal(t, []int64{1, 3, 5}, f.syncRepo.created)
+
+	disabled := newSyncerFixture(false)
+	require.NoError(t, disabled.syncer.Enqueue(ctx, &domain.Event{EventID: 1, EventType: domain.EventReviewerAssigned}))
+	assert.Empty(t, disabled.syncRepo.created)
+}
+
+func TestSyncerAppliesClaimedJobs(t *testing.T) {
+	f := newSyncerFixture(true)
+	f.syncRepo.tasks = []*domain.CodeHostSyncTask{
+		{Event: syncEvent(t, 1, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr1", ReviewerID: "u2"})},
+		{Event: syncEvent(t, 2, domain.EventReviewerReassigned, domain.ReviewerReassignedPayload{PullRequestID: "pr1", OldReviewerID: "u2", NewReviewerID: "u3"})},
+		{Event: syncEvent(t, 3, domain.EventReviewerUnassigned, domain.ReviewerUnassignedPayload{PullRequestID: "pr1", ReviewerID: "u3"})},
+		{Event: syncEvent(t, 4, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "local", ReviewerID: "u2"})},
+	}
+
+	require.NoError(t, f.syncer.SyncBatch(context.Background()))
+
+	assert.Equal(t, []clientCall{
+		{"request", "acme/api", 12, []string{"bob"}},
+		{"remove", "acme/api", 12, []string{"bob"}},
+		{"request", "acme/api", 12, []string{"carol"}},
+		{"remove", "acme/api", 12, []string{"carol"}},
+	}, f.client.calls)
+	assert.Equal(t, []int64{1, 2, 3, 4}, f.syncRepo.synced)
+	assert.Empty(t, f.syncRepo.failed)
+	assert.Empty(t, f.outboxRepo.appended)
+}
+
+func TestSyncerRetriesClientFailuresWithBackoff(t *testing.T) {
+	f := newSyncerFixture(true)
+	f.client.err = errors.New("github responded with status 502")
+	payload := domain.ReviewerAssignedPayload{PullRequestID: "pr1", ReviewerID: "u2"}
+	f.syncRepo.tasks = []*domain.CodeHostSyncTask{
+		{Attempts: 0, Event: syncEvent(t, 1, domain.EventReviewerAssigned, payload)},
+		{Attempts: 1, Event: syncEvent(t, 2, domain.EventReviewerAssigned, payload)},
+	}
+
+	require.NoError(t, f.syncer.SyncBatch(context.Background()))
+
+	assert.Empty(t, f.syncRepo.synced)
+	require.Len(t, f.syncRepo.failed, 2)
+	assert.Equal(t, "t1", f.syncRepo.failed[0].tenant)
+	assert.False(t, f.syncRepo.failed[0].dead)
+	assert.InDelta(t, time.Second, f.syncRepo.failed[0].delay, float64(500*time.Millisecond))
+	assert.False(t, f.syncRepo.failed[1].dead)
+	assert.InDelta(t, 2*time.Second, f.syncRepo.failed[1].delay, float64(500*time.Millisecond))
+	assert.Empty(t, f.outboxRepo.appended)
+}
+
+func TestSyncerReportsDeadJobs(t *testing.T) {
+	f := newSyncerFixture(true)
+	f.client.err = errors.New("github responded with status 502")
+	f.syncRepo.tasks = []*domain.CodeHostSyncTask{
+		{Attempts: 2, Event: syncEvent(t, 1, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr1", ReviewerID: "u2"})},
+		{Attempts: 0, Event: syncEvent(t, 2, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr1", ReviewerID: "unlinked"})},
+	}
+
+	require.NoError(t, f.syncer.SyncBatch(context.Background()))
+
+	require.Len(t, f.syncRepo.failed, 2)
+	assert.True(t, f.syncRepo.failed[0].dead)
+	assert.True(t, f.syncRepo.failed[1].dead, "a reviewer without a linked login cannot succeed on retry")
+
+	require.Len(t, f.outboxRepo.appended, 2)
+	var payload domain.CodeHostSyncFailedPayload
+	require.NoError(t, json.Unmarshal(f.outboxRepo.appended[0].Payload, &payload))
+	assert.Equal(t, domain.EventCodeHostSyncFailed, f.outboxRepo.appended[0].EventType)
+	assert.Equal(t, "request_reviewer", payload.Operation)
+	assert.Equal(t, "acme/api", payload.Repository)
+	assert.Equal(t, 12, payload.Number)
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -15,6 +18,49 @@ type Config struct {
 	AdminToken   string
 	UserToken    string
 	MigratorPath string
+	TenantTokens map[string]string
+
+	WebhookTimeout      time.Duration
+	WebhookPollInterval time.Duration
+	WebhookMaxAttempts  int
+	WebhookBaseBackoff  time.Duration
+	WebhookMaxBackoff   time.Duration
+
+	GitHubWebhookSecret  string
+	GitLabWebhookToken   string
+	GitHubWebhookSecrets map[string]string
+	GitLabWebhookTokens  map[string]string
+
+	GitHubAPIURL        string
+	GitHubToken         string
+	GitLabAPIURL        string
+	GitLabToken         string
+	CodeHostTimeout     time.Duration
+	CodeHostMaxAttempts int
+
+	CodeHostSyncMaxAttempts int
+
+	SLACheckInterval time.Duration
+
+	ReminderCron       string
+	ReminderNotifier   string
+	ReminderWebhookURL string
+
+	SMTPAddr              string
+	SMTPFrom              string
+	SMTPUsername          string
+	SMTPPassword          string
+	SMTPRecipientTemplate string
+
+	EmailTimeout     time.Duration
+	EmailMaxAttempts int
+	EmailBaseBackoff time.Duration
+
+	ChatTimeout     time.Duration
+	ChatMaxAttempts int
+	ChatBaseBackoff time.Duration
+
+	RandomSeed *int64
 }
 
 func Load() *Config {
@@ -28,6 +74,49 @@ func Load() *Config {
 		AdminToken:   getEnv("ADMIN_TOKEN", "admin-token"),
 		UserToken:    getEnv("USER_TOKEN", "user-token"),
 		MigratorPath: getEnv("/migrations", "migrations-path"),
+		TenantTokens: getEnvMap("TENANT_TOKENS"),
+
+		WebhookTimeout:      getEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second),
+		WebhookPollInterval: getEnvDuration("WEBHOOK_POLL_INTERVAL", time.Second),
+		WebhookMaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookBaseBackoff:  getEnvDuration("WEBHOOK_BASE_BACKOFF", time.Second),
+		WebhookMaxBackoff:   getEnvDuration("WEBHOOK_MAX_BACKOFF", 5*time.Minute),
+
+		GitHubWebhookSecret:  getEnv("GITHUB_WEBHOOK_SECRET", ""),
+		GitLabWebhookToken:   getEnv("GITLAB_WEBHOOK_TOKEN", ""),
+		GitHubWebhookSecrets: getEnvMap("GITHUB_WEBHOOK_SECRETS"),
+		GitLabWebhookTokens:  getEnvMap("GITLAB_WEBHOOK_TOKENS"),
+
+		GitHubAPIURL:        getEnv("GITHUB_API_URL", "https://api.github.com"),
+		GitHubToken:         getEnv("GITHUB_TOKEN", ""),
+		GitLabAPIURL:        getEnv("GITLAB_API_URL", "https://gitlab.com/api/v4"),
+		GitLabToken:         getEnv("GITLAB_TOKEN", ""),
+		CodeHostTimeout:     getEnvDuration("CODEHOST_TIMEOUT", 10*time.Second),
+		CodeHostMaxAttempts: getEnvInt("CODEHOST_MAX_ATTEMPTS", 3),
+
+		CodeHostSyncMaxAttempts: getEnvInt("CODEHOST_SYNC_MAX_ATTEMPTS", 8),
+
+		SLACheckInterval: getEnvDuration("SLA_CHECK_INTERVAL", time.Minute),
+
+		ReminderCron:       getEnv("REMINDER_CRON", "0 9 * * 1-5"),
+		ReminderNotifier:   getEnv("REMINDER_NOTIFIER", ""),
+		ReminderWebhookURL: getEnv("REMINDER_WEBHOOK_URL", ""),
+
+		SMTPAddr:              getEnv("SMTP_ADDR", ""),
+		SMTPFrom:              getEnv("SMTP_FROM", ""),
+		SMTPUsername:          getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:          getEnv("SMTP_PASSWORD", ""),
+		SMTPRecipientTemplate: getEnv("SMTP_RECIPIENT_TEMPLATE", ""),
+
+		EmailTimeout:     getEnvDuration("EMAIL_TIMEOUT", 10*time.Second),
+		EmailMaxAttempts: getEnvInt("EMAIL_MAX_ATTEMPTS", 5),
+		EmailBaseBackoff: getEnvDuration("EMAIL_BASE_BACKOFF", 5*time.Second),
+
+		ChatTimeout:     getEnvDuration("CHAT_TIMEOUT", 5*time.Second),
+		ChatMaxAttempts: getEnvInt("CHAT_MAX_ATTEMPTS", 5),
+		ChatBaseBackoff: getEnvDuration("CHAT_BASE_BACKOFF", 5*time.Second),
+
+		RandomSeed: getEnvInt64Ptr("RANDOM_SEED"),
 	}
 }
 
@@ -42,3 +131,35 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt64Ptr(key string) *int64 {
+	if value, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil {
+		return &value
+	}
+	return nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvMap(key string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && k != "" && v != "" {
+			values[k] = v
+		}
+	}
+	return values
+}
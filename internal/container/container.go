@@ -1,14 +1,23 @@
 package container
 
 import (
+	"avitotest/internal/codehost"
 	"avitotest/internal/config"
 	"avitotest/internal/domain"
+	"avitotest/internal/eventbus"
 	"avitotest/internal/handler"
+	"avitotest/internal/notify"
+	"avitotest/internal/reminder"
 	"avitotest/internal/repository"
+	"avitotest/internal/sla"
+	"avitotest/internal/stream"
 	"avitotest/internal/usecase"
+	"avitotest/internal/webhook"
 	"avitotest/pkg/logger"
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"time"
 )
 
 type Container struct {
@@ -16,16 +25,43 @@ type Container struct {
 
 	DB *sql.DB
 
-	TeamRepo        domain.TeamRepository
-	UserRepo        domain.UserRepository
-	PullRequestRepo domain.PullRequestRepository
+	TeamRepo         domain.TeamRepository
+	UserRepo         domain.UserRepository
+	PullRequestRepo  domain.PullRequestRepository
+	OutboxRepo       domain.OutboxRepository
+	WebhookRepo      domain.WebhookRepository
+	IntegrationRepo  domain.IntegrationRepository
+	CodeOwnersRepo   domain.CodeOwnersRepository
+	ExclusionRepo    domain.ExclusionRepository
+	OrgUnitRepo      domain.OrgUnitRepository
+	StatsRepo        domain.StatsRepository
+	SLARepo          domain.SLARepository
+	SyncRepo         domain.CodeHostSyncRepository
+	NotificationRepo domain.NotificationRepository
+	Transactor       domain.Transactor
+
+	EventBus  *eventbus.Bus
+	StreamHub *stream.Hub
 
 	TeamUseCase        *usecase.TeamUseCase
 	UserUseCase        *usecase.UserUseCase
 	PullRequestUseCase *usecase.PullRequestUseCase
+	WebhookUseCase     *usecase.WebhookUseCase
+	IntegrationUseCase *usecase.IntegrationUseCase
+	CodeOwnersUseCase  *usecase.CodeOwnersUseCase
+	ExclusionUseCase   *usecase.ExclusionUseCase
+	OrgUnitUseCase     *usecase.OrgUnitUseCase
+	ReminderUseCase    *usecase.ReminderUseCase
 
 	Router *handler.Router
 
+	WebhookDispatcher *webhook.Dispatcher
+	CodeHostSyncer    *codehost.Syncer
+	SLAScheduler      *sla.Scheduler
+	ReminderScheduler *reminder.Scheduler
+	EmailNotifier     *notify.EmailNotifier
+	ChatNotifier      *notify.ChatNotifier
+
 	Logger *slog.Logger
 }
 
@@ -43,12 +79,130 @@ func NewContainer() (*Container, error) {
 	teamRepo := repository.NewTeamRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	pullRequestRepo := repository.NewPullRequestRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	integrationRepo := repository.NewIntegrationRepository(db)
+	codeOwnersRepo := repository.NewCodeOwnersRepository(db)
+	exclusionRepo := repository.NewExclusionRepository(db)
+	orgUnitRepo := repository.NewOrgUnitRepository(db)
+	statsRepo := repository.NewStatsRepository(db)
+	slaRepo := repository.NewSLARepository(db)
+	syncRepo := repository.NewCodeHostSyncRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	transactor := repository.NewTransactor(db)
+
+	eventBus := eventbus.New()
+	streamHub := stream.NewHub(userRepo, pullRequestRepo, outboxRepo, stream.HubConfig{
+		QueueSize:        1000,
+		SubscriberBuffer: 100,
+		ReplayPageSize:   500,
+	}, logger)
+	eventBus.Subscribe(streamHub.Handle)
+
+	codeOwnersUseCase := usecase.NewCodeOwnersUseCase(codeOwnersRepo, userRepo, integrationRepo)
+	randomSeed := time.Now().UnixNano()
+	if cfg.RandomSeed != nil {
+		randomSeed = *cfg.RandomSeed
+	}
+	pullRequestUseCase := usecase.NewPullRequestUseCase(pullRequestRepo, userRepo, teamRepo, exclusionRepo, orgUnitRepo, slaRepo, outboxRepo, transactor, eventBus, codeOwnersUseCase, usecase.NewRandom(randomSeed))
+	teamUseCase := usecase.NewTeamUseCase(teamRepo, userRepo, transactor, pullRequestUseCase)
+	userUseCase := usecase.NewUserUseCase(userRepo, teamRepo, outboxRepo, transactor, eventBus, pullRequestUseCase)
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepo, outboxRepo)
+	exclusionUseCase := usecase.NewExclusionUseCase(exclusionRepo, userRepo)
+	orgUnitUseCase := usecase.NewOrgUnitUseCase(orgUnitRepo, teamRepo, statsRepo, transactor)
+	integrationUseCase := usecase.NewIntegrationUseCase(integrationRepo, userRepo, pullRequestRepo, pullRequestUseCase, transactor, map[domain.CodeHostProvider]domain.CodeHostUserDirectory{
+		domain.ProviderGitLab: codehost.NewGitLabClient(codehost.GitLabConfig{
+			BaseURL: cfg.GitLabAPIURL,
+			Token:   cfg.GitLabToken,
+			Timeout: cfg.CodeHostTimeout,
+		}),
+	})
+	reminderUseCase := usecase.NewReminderUseCase(userRepo, pullRequestRepo)
+
+	router := handler.NewRouter(
+		teamUseCase,
+		userUseCase,
+		pullRequestUseCase,
+		webhookUseCase,
+		integrationUseCase,
+		handler.IntegrationConfig{
+			GitHubWebhookSecrets: tenantSecrets(cfg.GitHubWebhookSecret, cfg.GitHubWebhookSecrets),
+			GitLabWebhookTokens:  tenantSecrets(cfg.GitLabWebhookToken, cfg.GitLabWebhookTokens),
+		},
+		codeOwnersUseCase,
+		exclusionUseCase,
+		orgUnitUseCase,
+		streamHub,
+		tenantTokens(cfg),
+		logger,
+	)
+
+	webhookDispatcher := webhook.NewDispatcher(outboxRepo, webhookRepo, transactor, webhook.NewSender(cfg.WebhookTimeout), webhook.DispatcherConfig{
+		PollInterval: cfg.WebhookPollInterval,
+		BatchSize:    100,
+		MaxAttempts:  cfg.WebhookMaxAttempts,
+		BaseBackoff:  cfg.WebhookBaseBackoff,
+		MaxBackoff:   cfg.WebhookMaxBackoff,
+	}, logger)
+
+	codeHostClients := make(map[domain.CodeHostProvider]domain.CodeHostClient)
+	if cfg.GitHubToken != "" {
+		codeHostClients[domain.ProviderGitHub] = codehost.NewGitHubClient(codehost.GitHubConfig{
+			BaseURL:     cfg.GitHubAPIURL,
+			Token:       cfg.GitHubToken,
+			Timeout:     cfg.CodeHostTimeout,
+			MaxAttempts: cfg.CodeHostMaxAttempts,
+			BaseBackoff: time.Second,
+		})
+	}
+	slaScheduler := sla.NewScheduler(slaRepo, pullRequestUseCase, sla.SchedulerConfig{
+		PollInterval: cfg.SLACheckInterval,
+		BatchSize:    100,
+	}, logger)
+
+	smtpNotifier := notify.NewSMTPNotifier(notify.SMTPConfig{
+		Addr:              cfg.SMTPAddr,
+		From:              cfg.SMTPFrom,
+		Username:          cfg.SMTPUsername,
+		Password:          cfg.SMTPPassword,
+		RecipientTemplate: cfg.SMTPRecipientTemplate,
+	})
+	reminderScheduler, err := newReminderScheduler(cfg, userRepo, reminderUseCase, smtpNotifier, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var emailNotifier *notify.EmailNotifier
+	if cfg.SMTPAddr != "" {
+		emailNotifier = notify.NewEmailNotifier(userRepo, pullRequestRepo, notificationRepo, smtpNotifier, notify.EmailConfig{
+			PollInterval: cfg.WebhookPollInterval,
+			BatchSize:    100,
+			MaxAttempts:  cfg.EmailMaxAttempts,
+			BaseBackoff:  cfg.EmailBaseBackoff,
+			MaxBackoff:   cfg.WebhookMaxBackoff,
+			Timeout:      cfg.EmailTimeout,
+		}, logger)
+		webhookDispatcher.AddFanOut(emailNotifier.Enqueue)
+	}
 
-	teamUseCase := usecase.NewTeamUseCase(teamRepo, userRepo)
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	pullRequestUseCase := usecase.NewPullRequestUseCase(pullRequestRepo, userRepo, teamRepo)
+	chatNotifier := notify.NewChatNotifier(userRepo, pullRequestRepo, teamRepo, notificationRepo, notify.ChatConfig{
+		PollInterval: cfg.WebhookPollInterval,
+		BatchSize:    100,
+		MaxAttempts:  cfg.ChatMaxAttempts,
+		BaseBackoff:  cfg.ChatBaseBackoff,
+		MaxBackoff:   cfg.WebhookMaxBackoff,
+		Timeout:      cfg.ChatTimeout,
+	}, logger)
+	webhookDispatcher.AddFanOut(chatNotifier.Enqueue)
 
-	router := handler.NewRouter(teamUseCase, userUseCase, pullRequestUseCase, logger)
+	codeHostSyncer := codehost.NewSyncer(integrationRepo, outboxRepo, syncRepo, codeHostClients, codehost.SyncerConfig{
+		PollInterval: cfg.WebhookPollInterval,
+		BatchSize:    100,
+		MaxAttempts:  cfg.CodeHostSyncMaxAttempts,
+		BaseBackoff:  cfg.WebhookBaseBackoff,
+		MaxBackoff:   cfg.WebhookMaxBackoff,
+	}, logger)
+	webhookDispatcher.AddFanOut(codeHostSyncer.Enqueue)
 
 	return &Container{
 		Config:             cfg,
@@ -56,9 +210,83 @@ func NewContainer() (*Container, error) {
 		TeamRepo:           teamRepo,
 		UserRepo:           userRepo,
 		PullRequestRepo:    pullRequestRepo,
+		OutboxRepo:         outboxRepo,
+		WebhookRepo:        webhookRepo,
+		IntegrationRepo:    integrationRepo,
+		CodeOwnersRepo:     codeOwnersRepo,
+		ExclusionRepo:      exclusionRepo,
+		OrgUnitRepo:        orgUnitRepo,
+		StatsRepo:          statsRepo,
+		SLARepo:            slaRepo,
+		SyncRepo:           syncRepo,
+		NotificationRepo:   notificationRepo,
+		Transactor:         transactor,
+		EventBus:           eventBus,
+		StreamHub:          streamHub,
 		TeamUseCase:        teamUseCase,
 		UserUseCase:        userUseCase,
 		PullRequestUseCase: pullRequestUseCase,
+		WebhookUseCase:     webhookUseCase,
+		IntegrationUseCase: integrationUseCase,
+		CodeOwnersUseCase:  codeOwnersUseCase,
+		ExclusionUseCase:   exclusionUseCase,
+		OrgUnitUseCase:     orgUnitUseCase,
+		ReminderUseCase:    reminderUseCase,
 		Router:             router,
+		WebhookDispatcher:  webhookDispatcher,
+		CodeHostSyncer:     codeHostSyncer,
+		SLAScheduler:       slaScheduler,
+		ReminderScheduler:  reminderScheduler,
+		EmailNotifier:      emailNotifier,
+		ChatNotifier:       chatNotifier,
+		Logger:             logger,
 	}, nil
 }
+
+func tenantTokens(cfg *config.Config) map[string]string {
+	tokens := map[string]string{
+		cfg.AdminToken: domain.DefaultTenantID,
+		cfg.UserToken:  domain.DefaultTenantID,
+	}
+	for token, tenantID := range cfg.TenantTokens {
+		tokens[token] = tenantID
+	}
+	return tokens
+}
+
+func tenantSecrets(defaultSecret string, secrets map[string]string) map[string]string {
+	merged := make(map[string]string, len(secrets)+1)
+	if defaultSecret != "" {
+		merged[domain.DefaultTenantID] = defaultSecret
+	}
+	for tenantID, secret := range secrets {
+		merged[tenantID] = secret
+	}
+	return merged
+}
+
+func newReminderScheduler(
+	cfg *config.Config,
+	userRepo domain.UserRepository,
+	builder reminder.DigestBuilder,
+	smtpNotifier *notify.SMTPNotifier,
+	logger *slog.Logger,
+) (*reminder.Scheduler, error) {
+	var notifier domain.DigestNotifier
+	switch cfg.ReminderNotifier {
+	case "":
+		return nil, nil
+	case "smtp":
+		notifier = smtpNotifier
+	case "webhook":
+		notifier = notify.NewWebhookNotifier(cfg.ReminderWebhookURL, cfg.WebhookTimeout)
+	default:
+		return nil, fmt.Errorf("unknown reminder notifier %q", cfg.ReminderNotifier)
+	}
+
+	schedule, err := reminder.ParseSchedule(cfg.ReminderCron)
+	if err != nil {
+		return nil, err
+	}
+	return reminder.NewScheduler(userRepo, builder, notifier, schedule, logger), nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+type AssignmentStrategy string
+
+const (
+	AssignmentStrategyRandom    AssignmentStrategy = "random"
+	AssignmentStrategyExpertise AssignmentStrategy = "expertise_ranked"
+)
+
+type AssignmentReason string
+
+const (
+	AssignmentReasonLead       AssignmentReason = "lead"
+	AssignmentReasonCodeOwner  AssignmentReason = "codeowner"
+	AssignmentReasonTeam       AssignmentReason = "team"
+	AssignmentReasonExpert     AssignmentReason = "expert"
+	AssignmentReasonSenior     AssignmentReason = "senior"
+	AssignmentReasonDepartment AssignmentReason = "department"
+	AssignmentReasonReassigned AssignmentReason = "reassigned"
+	AssignmentReasonSLABackup  AssignmentReason = "sla_backup"
+)
+
+type AssignmentFilter struct {
+	Name    string   `json:"name"`
+	Removed []string `json:"removed"`
+}
+
+type ReviewerChoice struct {
+	UserID string           `json:"user_id"`
+	Reason AssignmentReason `json:"reason"`
+	Score  int              `json:"score"`
+}
+
+type AssignmentExplanation struct {
+	Strategy         AssignmentStrategy `json:"strategy"`
+	Seed             *int64             `json:"seed,omitempty"`
+	PoolSize         int                `json:"pool_size"`
+	EligibleCount    int                `json:"eligible_count"`
+	FallbackPoolSize int                `json:"fallback_pool_size,omitempty"`
+	Filters          []AssignmentFilter `json:"filters"`
+	Reviewers        []ReviewerChoice   `json:"reviewers"`
+	AssignedAt       time.Time          `json:"assigned_at"`
+}
+
+func (e *AssignmentExplanation) RemovedBy(filterName string) []string {
+	if e == nil {
+		return nil
+	}
+	for _, filter := range e.Filters {
+		if filter.Name == filterName && len(filter.Removed) > 0 {
+			return filter.Removed
+		}
+	}
+	return nil
+}
+
+type AssignmentPreview struct {
+	AuthorID          string                 `json:"author_id"`
+	AssignedReviewers []string               `json:"assigned_reviewers"`
+	Assignment        *AssignmentExplanation `json:"assignment"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+type CodeOwnersScope string
+
+const (
+	CodeOwnersScopeTeam       CodeOwnersScope = "team"
+	CodeOwnersScopeRepository CodeOwnersScope = "repository"
+)
+
+type CodeOwnersRuleset struct {
+	Scope     CodeOwnersScope `json:"scope"`
+	ScopeName string          `json:"scope_name"`
+	Content   string          `json:"content"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
@@ -5,12 +5,16 @@ import "fmt"
 type ErrorCode string
 
 const (
-	ErrorCodeTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrorCodePRExists    ErrorCode = "PR_EXISTS"
-	ErrorCodePRMerged    ErrorCode = "PR_MERGED"
-	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	ErrorCodeTeamExists    ErrorCode = "TEAM_EXISTS"
+	ErrorCodePRExists      ErrorCode = "PR_EXISTS"
+	ErrorCodeOrgUnitExists ErrorCode = "ORG_UNIT_EXISTS"
+	ErrorCodePRMerged      ErrorCode = "PR_MERGED"
+	ErrorCodePRClosed      ErrorCode = "PR_CLOSED"
+	ErrorCodeNotAssigned   ErrorCode = "NOT_ASSIGNED"
+	ErrorCodeNoCandidate   ErrorCode = "NO_CANDIDATE"
+	ErrorCodeNotFound      ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation    ErrorCode = "VALIDATION_ERROR"
+	ErrorCodeUnauthorized  ErrorCode = "UNAUTHORIZED"
 )
 
 type DomainError struct {
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventPRCreated           EventType = "PRCreated"
+	EventReviewerAssigned    EventType = "ReviewerAssigned"
+	EventReviewerReassigned  EventType = "ReviewerReassigned"
+	EventReviewerUnassigned  EventType = "ReviewerUnassigned"
+	EventPRMerged            EventType = "PRMerged"
+	EventPRClosed            EventType = "PRClosed"
+	EventPRReopened          EventType = "PRReopened"
+	EventUserActivityChanged EventType = "UserActivityChanged"
+	EventCodeHostSyncFailed  EventType = "CodeHostSyncFailed"
+	EventSLABreached         EventType = "SLABreached"
+)
+
+var EventTypes = []EventType{
+	EventPRCreated,
+	EventReviewerAssigned,
+	EventReviewerReassigned,
+	EventReviewerUnassigned,
+	EventPRMerged,
+	EventPRClosed,
+	EventPRReopened,
+	EventUserActivityChanged,
+	EventCodeHostSyncFailed,
+	EventSLABreached,
+}
+
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+type EventStatus string
+
+const (
+	EventStatusPending   EventStatus = "PENDING"
+	EventStatusDelivered EventStatus = "DELIVERED"
+	EventStatusDead      EventStatus = "DEAD"
+)
+
+type Event struct {
+	EventID     int64           `json:"event_id"`
+	TenantID    string          `json:"-"`
+	EventType   EventType       `json:"event_type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+type OutboxEntry struct {
+	Event
+	Status    EventStatus
+	Attempts  int
+	LastError string
+}
+
+type PRCreatedPayload struct {
+	PullRequest *PullRequest `json:"pr"`
+}
+
+type ReviewerAssignedPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+type ReviewerReassignedPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type ReviewerUnassignedPayload struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+type PRMergedPayload struct {
+	PullRequest *PullRequest `json:"pr"`
+}
+
+type PRStatusChangedPayload struct {
+	PullRequest *PullRequest `json:"pr"`
+}
+
+type UserActivityChangedPayload struct {
+	UserID   string `json:"user_id"`
+	IsActive bool   `json:"is_active"`
+}
+
+type CodeHostSyncFailedPayload struct {
+	PullRequestID string           `json:"pull_request_id"`
+	Provider      CodeHostProvider `json:"provider"`
+	Repository    string           `json:"repository"`
+	Number        int              `json:"number"`
+	Operation     string           `json:"operation"`
+	Error         string           `json:"error"`
+}
+
+type SLABreachedPayload struct {
+	PullRequestID    string  `json:"pull_request_id"`
+	TeamName         string  `json:"team_name"`
+	Kind             SLAKind `json:"kind"`
+	SLASeconds       int     `json:"sla_seconds"`
+	BackupReviewerID string  `json:"backup_reviewer_id,omitempty"`
+}
+
+func NewEvent(eventType EventType, aggregateID string, payload interface{}) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+	return &Event{
+		EventType:   eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		OccurredAt:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type ReviewerExclusion struct {
+	ExclusionID    int64     `json:"exclusion_id"`
+	UserID         string    `json:"user_id"`
+	ExcludedUserID string    `json:"excluded_user_id"`
+	Symmetric      bool      `json:"symmetric"`
+	Reason         string    `json:"reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "context"
+
+type CodeHostProvider string
+
+const (
+	ProviderGitHub CodeHostProvider = "github"
+	ProviderGitLab CodeHostProvider = "gitlab"
+)
+
+func (p CodeHostProvider) IsValid() bool {
+	switch p {
+	case ProviderGitHub, ProviderGitLab:
+		return true
+	}
+	return false
+}
+
+type CodeHostAction string
+
+const (
+	CodeHostActionOpened   CodeHostAction = "opened"
+	CodeHostActionClosed   CodeHostAction = "closed"
+	CodeHostActionReopened CodeHostAction = "reopened"
+	CodeHostActionMerged   CodeHostAction = "merged"
+)
+
+type ExternalIdentity struct {
+	Provider      CodeHostProvider `json:"provider"`
+	ExternalLogin string           `json:"external_login"`
+	UserID        string           `json:"user_id"`
+}
+
+type ExternalPullRequest struct {
+	PullRequestID string           `json:"pull_request_id"`
+	Provider      CodeHostProvider `json:"provider"`
+	Repository    string           `json:"repository"`
+	Number        int              `json:"number"`
+}
+
+type CodeHostPullRequestEvent struct {
+	Provider         CodeHostProvider
+	Action           CodeHostAction
+	Repository       string
+	Number           int
+	Title            string
+	AuthorLogin      string
+	AuthorExternalID int64
+}
+
+type CodeHostClient interface {
+	RequestReviewers(ctx context.Context, repository string, number int, logins []string) error
+	RemoveReviewers(ctx context.Context, repository string, number int, logins []string) error
+}
+
+type CodeHostSyncTask struct {
+	Attempts int
+	Event    Event
+}
+
+type CodeHostUserDirectory interface {
+	GetLogin(ctx context.Context, externalID int64) (string, error)
+}
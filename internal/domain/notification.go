@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ReviewDigest struct {
+	UserID       string              `json:"user_id"`
+	Username     string              `json:"username"`
+	Email        string              `json:"email,omitempty"`
+	TeamName     string              `json:"team_name"`
+	PullRequests []*PullRequestShort `json:"pull_requests"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+}
+
+type DigestNotifier interface {
+	SendDigest(ctx context.Context, digest *ReviewDigest) error
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelChat  NotificationChannel = "chat"
+)
+
+type NotificationTask struct {
+	JobID     int64
+	Attempts  int
+	Recipient string
+	Event     Event
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type OrgUnit struct {
+	UnitID    string    `json:"unit_id"`
+	Name      string    `json:"name"`
+	ParentID  *string   `json:"parent_id,omitempty"`
+	Children  []string  `json:"children"`
+	Teams     []string  `json:"teams"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TeamStats struct {
+	TeamName      string `json:"team_name"`
+	Members       int    `json:"members"`
+	ActiveMembers int    `json:"active_members"`
+	OpenPRs       int    `json:"open_prs"`
+	MergedPRs     int    `json:"merged_prs"`
+	ClosedPRs     int    `json:"closed_prs"`
+	OpenReviews   int    `json:"open_reviews"`
+	SLABreaches   int    `json:"sla_breaches"`
+}
+
+type OrgUnitStats struct {
+	UnitID string       `json:"unit_id"`
+	Totals TeamStats    `json:"totals"`
+	Teams  []*TeamStats `json:"teams"`
+}
@@ -1,22 +1,32 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type PRStatus string
 
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
 type PullRequest struct {
 	PullRequestID     string     `json:"pull_request_id" db:"pull_request_id"`
 	PullRequestName   string     `json:"pull_request_name" db:"pull_request_name"`
 	AuthorID          string     `json:"author_id" db:"author_id"`
+	CoAuthorIDs       []string   `json:"co_author_ids,omitempty" db:"co_author_ids"`
+	ReviewTeam        string     `json:"review_team,omitempty" db:"review_team"`
 	Status            PRStatus   `json:"status" db:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers" db:"assigned_reviewers"`
+	Labels            []string   `json:"labels,omitempty" db:"labels"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty" db:"created_at"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
+	FirstVerdictAt    *time.Time `json:"first_verdict_at,omitempty" db:"first_verdict_at"`
+
+	Assignment *AssignmentExplanation `json:"assignment,omitempty" db:"assignment"`
 }
 
 type PullRequestShort struct {
@@ -25,3 +35,27 @@ type PullRequestShort struct {
 	AuthorID        string   `json:"author_id"`
 	Status          PRStatus `json:"status"`
 }
+
+type ReviewerInfo struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+}
+
+type PullRequestDetails struct {
+	PullRequest
+	TeamName   string         `json:"team_name"`
+	Reviewers  []ReviewerInfo `json:"reviewers"`
+	AgeSeconds int64          `json:"age_seconds"`
+
+	SLABreaches []*SLABreach        `json:"sla_breaches"`
+	Verdicts    []*SubmittedVerdict `json:"verdicts"`
+}
+
+func (pr *PullRequest) AuthorIDs() []string {
+	if pr.AuthorID == "" {
+		return slices.Clone(pr.CoAuthorIDs)
+	}
+	return append([]string{pr.AuthorID}, pr.CoAuthorIDs...)
+}
@@ -1,18 +1,37 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type UserRepository interface {
 	CreateOrUpdate(ctx context.Context, user *User) error
 	GetByID(ctx context.Context, userID string) (*User, error)
+	GetByIDs(ctx context.Context, userIDs []string) ([]*User, error)
 	GetByTeamName(ctx context.Context, teamName string) ([]*User, error)
+	List(ctx context.Context, filter UserFilter) ([]*User, int, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
+	SetSeniority(ctx context.Context, userID string, seniority Seniority) error
+	SetRole(ctx context.Context, userID string, role TeamRole) error
+	Archive(ctx context.Context, userID string) error
+	AddMembership(ctx context.Context, userID, teamName string) error
+	RemoveMembership(ctx context.Context, userID, teamName string) error
+	GetTags(ctx context.Context, userIDs []string) (map[string][]string, error)
+	SetTags(ctx context.Context, userID string, tags []string) error
+	ListTenantIDs(ctx context.Context) ([]string, error)
 }
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *Team) error
 	GetByName(ctx context.Context, teamName string) (*Team, error)
 	Exists(ctx context.Context, teamName string) (bool, error)
+	GetPolicy(ctx context.Context, teamName string) (*TeamPolicy, error)
+	SetPolicy(ctx context.Context, policy *TeamPolicy) error
+	GetChatChannel(ctx context.Context, teamName string) (*TeamChatChannel, error)
+	SetChatChannel(ctx context.Context, channel *TeamChatChannel) error
+	Rename(ctx context.Context, teamName, newTeamName string) error
+	DeleteSettings(ctx context.Context, teamName string) error
 }
 
 type PullRequestRepository interface {
@@ -22,3 +41,99 @@ type PullRequestRepository interface {
 	Update(ctx context.Context, pr *PullRequest) error
 	Exists(ctx context.Context, prID string) (bool, error)
 }
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event *Event)
+}
+
+type OutboxRepository interface {
+	Append(ctx context.Context, events ...*Event) error
+	GetByID(ctx context.Context, eventID int64) (*Event, error)
+	ListAfter(ctx context.Context, afterID int64, eventTypes []EventType, limit int) ([]*Event, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*OutboxEntry, error)
+	MarkDelivered(ctx context.Context, eventID int64) error
+	MarkFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	GetSubscription(ctx context.Context, subscriptionID int64) (*WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, subscriptionID int64) error
+
+	CreateDelivery(ctx context.Context, subscriptionID, eventID int64) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]*WebhookDelivery, error)
+	ListAttempts(ctx context.Context, deliveryIDs []int64) ([]*DeliveryAttempt, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDeliveryTask, error)
+	RecordAttempt(ctx context.Context, attempt *DeliveryAttempt) error
+	MarkDeliveryDelivered(ctx context.Context, deliveryID int64) error
+	MarkDeliveryFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt time.Time, dead bool) error
+}
+
+type IntegrationRepository interface {
+	LinkIdentity(ctx context.Context, identity *ExternalIdentity) error
+	UnlinkIdentity(ctx context.Context, provider CodeHostProvider, externalLogin string) error
+	GetUserIDByLogin(ctx context.Context, provider CodeHostProvider, externalLogin string) (string, error)
+	GetLoginByUserID(ctx context.Context, provider CodeHostProvider, userID string) (string, error)
+	ListIdentitiesByUserID(ctx context.Context, userID string) ([]*ExternalIdentity, error)
+
+	LinkPullRequest(ctx context.Context, link *ExternalPullRequest) error
+	GetPullRequestLink(ctx context.Context, provider CodeHostProvider, repository string, number int) (*ExternalPullRequest, error)
+	GetPullRequestLinkByID(ctx context.Context, prID string) (*ExternalPullRequest, error)
+}
+
+type CodeHostSyncRepository interface {
+	CreateJob(ctx context.Context, eventID int64) error
+	ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]*CodeHostSyncTask, error)
+	MarkJobSynced(ctx context.Context, eventID int64) error
+	MarkJobFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error
+}
+
+type NotificationRepository interface {
+	CreateJob(ctx context.Context, channel NotificationChannel, eventID int64, recipient string) error
+	ClaimDueJobs(ctx context.Context, channel NotificationChannel, limit int, lease time.Duration) ([]*NotificationTask, error)
+	MarkJobDelivered(ctx context.Context, jobID int64) error
+	MarkJobFailed(ctx context.Context, jobID int64, lastError string, nextAttemptAt time.Time, dead bool) error
+}
+
+type CodeOwnersRepository interface {
+	Upsert(ctx context.Context, ruleset *CodeOwnersRuleset) error
+	Get(ctx context.Context, scope CodeOwnersScope, scopeName string) (*CodeOwnersRuleset, error)
+}
+
+type ExclusionRepository interface {
+	Upsert(ctx context.Context, exclusion *ReviewerExclusion) error
+	Delete(ctx context.Context, exclusionID int64) error
+	ListByUserID(ctx context.Context, userID string) ([]*ReviewerExclusion, error)
+	GetExcludedReviewers(ctx context.Context, authorIDs []string) ([]string, error)
+}
+
+type OrgUnitRepository interface {
+	Create(ctx context.Context, unit *OrgUnit) error
+	Get(ctx context.Context, unitID string) (*OrgUnit, error)
+	List(ctx context.Context) ([]*OrgUnit, error)
+	Update(ctx context.Context, unit *OrgUnit) error
+	Delete(ctx context.Context, unitID string) error
+	GetAncestorIDs(ctx context.Context, unitID string) ([]string, error)
+	SetTeamUnit(ctx context.Context, teamName string, unitID *string) error
+	GetUnitIDByTeam(ctx context.Context, teamName string) (string, error)
+	GetSubtreeTeams(ctx context.Context, unitID string) ([]string, error)
+}
+
+type SLARepository interface {
+	FindBreaches(ctx context.Context, now time.Time, limit int) ([]*SLABreach, error)
+	RecordBreach(ctx context.Context, breach *SLABreach) (bool, error)
+	ListByPullRequest(ctx context.Context, prID string) ([]*SLABreach, error)
+	RecordVerdict(ctx context.Context, verdict *SubmittedVerdict) error
+	ListVerdicts(ctx context.Context, prID string) ([]*SubmittedVerdict, error)
+}
+
+type StatsRepository interface {
+	GetTeamStats(ctx context.Context, teamNames []string) ([]*TeamStats, error)
+}
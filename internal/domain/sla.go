@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+type SLAKind string
+
+const (
+	SLAKindFirstVerdict SLAKind = "first_verdict"
+	SLAKindMerge        SLAKind = "merge"
+)
+
+type ReviewVerdict string
+
+const (
+	ReviewVerdictApproved         ReviewVerdict = "approved"
+	ReviewVerdictChangesRequested ReviewVerdict = "changes_requested"
+)
+
+func (v ReviewVerdict) IsValid() bool {
+	return v == ReviewVerdictApproved || v == ReviewVerdictChangesRequested
+}
+
+type SubmittedVerdict struct {
+	VerdictID     int64         `json:"verdict_id"`
+	PullRequestID string        `json:"pull_request_id"`
+	ReviewerID    string        `json:"reviewer_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+	SubmittedAt   time.Time     `json:"submitted_at"`
+}
+
+type SLABreach struct {
+	BreachID         int64     `json:"breach_id"`
+	TenantID         string    `json:"-"`
+	PullRequestID    string    `json:"pull_request_id"`
+	TeamName         string    `json:"team_name"`
+	Kind             SLAKind   `json:"kind"`
+	SLASeconds       int       `json:"sla_seconds"`
+	BreachedAt       time.Time `json:"breached_at"`
+	BackupReviewerID string    `json:"backup_reviewer_id,omitempty"`
+}
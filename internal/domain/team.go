@@ -1,12 +1,53 @@
 package domain
 
+type TeamRole string
+
+const (
+	TeamRoleMember TeamRole = "member"
+	TeamRoleLead   TeamRole = "lead"
+)
+
+func (r TeamRole) IsValid() bool {
+	return r == TeamRoleMember || r == TeamRoleLead
+}
+
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	Seniority Seniority `json:"seniority,omitempty"`
+	Role      TeamRole  `json:"role,omitempty"`
+	IsPrimary bool      `json:"is_primary"`
+}
+
+type TeamChatChannel struct {
+	TeamName   string `json:"team_name"`
+	WebhookURL string `json:"webhook_url"`
+	Channel    string `json:"channel,omitempty"`
 }
 
 type Team struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
 }
+
+type TeamPolicy struct {
+	TeamName           string   `json:"team_name"`
+	MinSeniorReviewers int      `json:"min_senior_reviewers"`
+	LeadReviewLabels   []string `json:"lead_review_labels"`
+
+	FirstVerdictSLASeconds int `json:"first_verdict_sla_seconds"`
+	MergeSLASeconds        int `json:"merge_sla_seconds"`
+}
+
+func (p *TeamPolicy) RequiresLead(labels []string) bool {
+	for _, label := range labels {
+		for _, required := range p.LeadReviewLabels {
+			if label == required {
+				return true
+			}
+		}
+	}
+	return false
+}
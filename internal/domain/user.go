@@ -1,8 +1,82 @@
 package domain
 
+import (
+	"slices"
+	"time"
+)
+
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityMiddle Seniority = "middle"
+	SenioritySenior Seniority = "senior"
+)
+
+func (s Seniority) IsValid() bool {
+	switch s {
+	case SeniorityJunior, SeniorityMiddle, SenioritySenior:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
-	TeamName string `json:"team_name" db:"team_name"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	UserID      string    `json:"user_id" db:"user_id"`
+	Username    string    `json:"username" db:"username"`
+	Email       string    `json:"email,omitempty" db:"email"`
+	ChatMention string    `json:"chat_mention,omitempty" db:"chat_mention"`
+	TeamName    string    `json:"team_name" db:"team_name"`
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	Seniority   Seniority `json:"seniority" db:"seniority"`
+	Role        TeamRole  `json:"role" db:"role"`
+	Teams       []string  `json:"teams" db:"-"`
+
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+}
+
+type UserFilter struct {
+	TeamName string
+	IsActive *bool
+	Limit    int
+	Offset   int
+}
+
+type ReviewPolicy string
+
+const (
+	ReviewPolicyKeep     ReviewPolicy = "keep"
+	ReviewPolicyReassign ReviewPolicy = "reassign"
+	ReviewPolicyUnassign ReviewPolicy = "unassign"
+)
+
+func (p ReviewPolicy) IsValid() bool {
+	switch p {
+	case ReviewPolicyKeep, ReviewPolicyReassign, ReviewPolicyUnassign:
+		return true
+	}
+	return false
+}
+
+type ReviewRelease struct {
+	Released   []string
+	Unassigned []string
+}
+
+func NewReviewRelease() *ReviewRelease {
+	return &ReviewRelease{Released: []string{}, Unassigned: []string{}}
+}
+
+func (r *ReviewRelease) Add(other *ReviewRelease) {
+	r.Released = appendMissing(r.Released, other.Released)
+	r.Unassigned = appendMissing(r.Unassigned, other.Unassigned)
+}
+
+func appendMissing(values, more []string) []string {
+	for _, value := range more {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
 }
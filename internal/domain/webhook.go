@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type WebhookSubscription struct {
+	SubscriptionID int64       `json:"subscription_id"`
+	URL            string      `json:"url"`
+	EventTypes     []EventType `json:"event_types"`
+	Secret         string      `json:"-"`
+	IsActive       bool        `json:"is_active"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+func (s *WebhookSubscription) Matches(eventType EventType) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	DeliveryID     int64              `json:"delivery_id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	EventID        int64              `json:"event_id"`
+	EventType      EventType          `json:"event_type"`
+	Status         EventStatus        `json:"status"`
+	Attempts       int                `json:"attempts"`
+	LastError      string             `json:"last_error,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	DeliveredAt    *time.Time         `json:"delivered_at,omitempty"`
+	AttemptLog     []*DeliveryAttempt `json:"attempt_log"`
+}
+
+type DeliveryAttempt struct {
+	AttemptID   int64     `json:"attempt_id"`
+	DeliveryID  int64     `json:"delivery_id"`
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+}
+
+type WebhookDeliveryTask struct {
+	DeliveryID     int64
+	SubscriptionID int64
+	Attempts       int
+	URL            string
+	Secret         string
+	Event          Event
+}
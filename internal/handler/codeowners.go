@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"avitotest/internal/domain"
+	"avitotest/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CodeOwnersHandler struct {
+	codeOwnersUseCase *usecase.CodeOwnersUseCase
+}
+
+func NewCodeOwnersHandler(codeOwnersUseCase *usecase.CodeOwnersUseCase) *CodeOwnersHandler {
+	return &CodeOwnersHandler{
+		codeOwnersUseCase: codeOwnersUseCase,
+	}
+}
+
+func (h *CodeOwnersHandler) SetRuleset(c echo.Context) error {
+	var req struct {
+		TeamName   string `json:"team_name"`
+		Repository string `json:"repository"`
+		Content    string `json:"content"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	scope, scopeName, err := codeOwnersScope(req.TeamName, req.Repository)
+	if err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	ruleset, err := h.codeOwnersUseCase.SetRuleset(c.Request().Context(), &domain.CodeOwnersRuleset{
+		Scope:     scope,
+		ScopeName: scopeName,
+		Content:   req.Content,
+	})
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"codeowners": ruleset,
+	})
+}
+
+func (h *CodeOwnersHandler) GetRuleset(c echo.Context) error {
+	scope, scopeName, err := codeOwnersScope(c.QueryParam("team_name"), c.QueryParam("repository"))
+	if err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	ruleset, err := h.codeOwnersUseCase.GetRuleset(c.Request().Context(), scope, scopeName)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"codeowners": ruleset,
+	})
+}
+
+func codeOwnersScope(teamName, repository string) (domain.CodeOwnersScope, string, error) {
+	switch {
+	case teamName != "" && repository == "":
+		return domain.CodeOwnersScopeTeam, teamName, nil
+	case repository != "" && teamName == "":
+		return domain.CodeOwnersScopeRepository, repository, nil
+	}
+	return "", "", domain.NewDomainError(domain.ErrorCodeValidation, "exactly one of team_name or repository is required")
+}
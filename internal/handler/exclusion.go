@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"avitotest/internal/domain"
+	"avitotest/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ExclusionHandler struct {
+	exclusionUseCase *usecase.ExclusionUseCase
+}
+
+func NewExclusionHandler(exclusionUseCase *usecase.ExclusionUseCase) *ExclusionHandler {
+	return &ExclusionHandler{
+		exclusionUseCase: exclusionUseCase,
+	}
+}
+
+func (h *ExclusionHandler) AddExclusion(c echo.Context) error {
+	var req struct {
+		UserID         string `json:"user_id"`
+		ExcludedUserID string `json:"excluded_user_id"`
+		Symmetric      *bool  `json:"symmetric"`
+		Reason         string `json:"reason"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	symmetric := true
+	if req.Symmetric != nil {
+		symmetric = *req.Symmetric
+	}
+
+	exclusion, err := h.exclusionUseCase.AddExclusion(c.Request().Context(), &domain.ReviewerExclusion{
+		UserID:         req.UserID,
+		ExcludedUserID: req.ExcludedUserID,
+		Symmetric:      symmetric,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 201, map[string]interface{}{
+		"exclusion": exclusion,
+	})
+}
+
+func (h *ExclusionHandler) DeleteExclusion(c echo.Context) error {
+	var req struct {
+		ExclusionID int64 `json:"exclusion_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	if err := h.exclusionUseCase.DeleteExclusion(c.Request().Context(), req.ExclusionID); err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"exclusion_id": req.ExclusionID,
+	})
+}
+
+func (h *ExclusionHandler) ListExclusions(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "user_id is required"), 400)
+	}
+
+	exclusions, err := h.exclusionUseCase.ListExclusions(c.Request().Context(), userID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user_id":    userID,
+		"exclusions": exclusions,
+	})
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/usecase"
+	"avitotest/internal/webhook"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IntegrationConfig struct {
+	GitHubWebhookSecrets map[string]string
+	GitLabWebhookTokens  map[string]string
+}
+
+type IntegrationHandler struct {
+	integrationUseCase *usecase.IntegrationUseCase
+	cfg                IntegrationConfig
+}
+
+func NewIntegrationHandler(integrationUseCase *usecase.IntegrationUseCase, cfg IntegrationConfig) *IntegrationHandler {
+	return &IntegrationHandler{
+		integrationUseCase: integrationUseCase,
+		cfg:                cfg,
+	}
+}
+
+type githubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func (p *githubPullRequestPayload) toEvent() (*domain.CodeHostPullRequestEvent, bool) {
+	event := &domain.CodeHostPullRequestEvent{
+		Provider:    domain.ProviderGitHub,
+		Repository:  p.Repository.FullName,
+		Number:      p.PullRequest.Number,
+		Title:       p.PullRequest.Title,
+		AuthorLogin: p.PullRequest.User.Login,
+	}
+
+	switch p.Action {
+	case "opened":
+		event.Action = domain.CodeHostActionOpened
+	case "reopened":
+		event.Action = domain.CodeHostActionReopened
+	case "closed":
+		event.Action = domain.CodeHostActionClosed
+		if p.PullRequest.Merged {
+			event.Action = domain.CodeHostActionMerged
+		}
+	default:
+		return nil, false
+	}
+	return event, true
+}
+
+type gitlabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		Action   string `json:"action"`
+		AuthorID int64  `json:"author_id"`
+	} `json:"object_attributes"`
+}
+
+func (p *gitlabMergeRequestPayload) toEvent() (*domain.CodeHostPullRequestEvent, bool) {
+	event := &domain.CodeHostPullRequestEvent{
+		Provider:         domain.ProviderGitLab,
+		Repository:       p.Project.PathWithNamespace,
+		Number:           p.ObjectAttributes.IID,
+		Title:            p.ObjectAttributes.Title,
+		AuthorExternalID: p.ObjectAttributes.AuthorID,
+	}
+	if p.ObjectAttributes.AuthorID != 0 && p.ObjectAttributes.AuthorID == p.User.ID {
+		event.AuthorLogin = p.User.Username
+	}
+
+	switch p.ObjectAttributes.Action {
+	case "open":
+		event.Action = domain.CodeHostActionOpened
+	case "reopen":
+		event.Action = domain.CodeHostActionReopened
+	case "close":
+		event.Action = domain.CodeHostActionClosed
+	case "merge":
+		event.Action = domain.CodeHostActionMerged
+	default:
+		return nil, false
+	}
+	return event, true
+}
+
+func webhookTenant(c echo.Context) string {
+	if tenantID := c.Param("tenant_id"); tenantID != "" {
+		return tenantID
+	}
+	return domain.DefaultTenantID
+}
+
+func (h *IntegrationHandler) GitHubWebhook(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	tenantID := webhookTenant(c)
+	secret := h.cfg.GitHubWebhookSecrets[tenantID]
+	if secret == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeUnauthorized, "github webhook secret is not configured for tenant"), 0)
+	}
+	signature := c.Request().Header.Get("X-Hub-Signature-256")
+	if !hmac.Equal([]byte(signature), []byte(webhook.Sign(secret, body))) {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeUnauthorized, "invalid signature"), 0)
+	}
+	ctx := domain.WithTenant(c.Request().Context(), tenantID)
+
+	switch c.Request().Header.Get("X-GitHub-Event") {
+	case "ping":
+		return WriteJSON(c, 200, map[string]interface{}{"status": "pong"})
+	case "pull_request":
+	default:
+		return WriteJSON(c, 202, map[string]interface{}{"status": "ignored"})
+	}
+
+	var payload githubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	event, ok := payload.toEvent()
+	if !ok {
+		return WriteJSON(c, 202, map[string]interface{}{"status": "ignored"})
+	}
+
+	pr, err := h.integrationUseCase.HandlePullRequestEvent(ctx, event)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *IntegrationHandler) GitLabWebhook(c echo.Context) error {
+	tenantID := webhookTenant(c)
+	expected := h.cfg.GitLabWebhookTokens[tenantID]
+	if expected == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeUnauthorized, "gitlab webhook token is not configured for tenant"), 0)
+	}
+	token := c.Request().Header.Get("X-Gitlab-Token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeUnauthorized, "invalid token"), 0)
+	}
+	ctx := domain.WithTenant(c.Request().Context(), tenantID)
+
+	if c.Request().Header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+		return WriteJSON(c, 202, map[string]interface{}{"status": "ignored"})
+	}
+
+	var payload gitlabMergeRequestPayload
+	if err := json.NewDecoder(c.Request().Body).Decode(&payload); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	event, ok := payload.toEvent()
+	if !ok || payload.ObjectKind != "merge_request" {
+		return WriteJSON(c, 202, map[string]interface{}{"status": "ignored"})
+	}
+
+	pr, err := h.integrationUseCase.HandlePullRequestEvent(ctx, event)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *IntegrationHandler) LinkIdentity(c echo.Context) error {
+	var req domain.ExternalIdentity
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	identity, err := h.integrationUseCase.LinkIdentity(c.Request().Context(), &req)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"identity": identity,
+	})
+}
+
+func (h *IntegrationHandler) UnlinkIdentity(c echo.Context) error {
+	var req struct {
+		Provider      domain.CodeHostProvider `json:"provider"`
+		ExternalLogin string                  `json:"external_login"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	if err := h.integrationUseCase.UnlinkIdentity(c.Request().Context(), req.Provider, req.ExternalLogin); err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"provider":       req.Provider,
+		"external_login": req.ExternalLogin,
+	})
+}
+
+func (h *IntegrationHandler) ListIdentities(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "user_id is required"), 400)
+	}
+
+	identities, err := h.integrationUseCase.ListIdentities(c.Request().Context(), userID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user_id":    userID,
+		"identities": identities,
+	})
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/usecase"
+	"avitotest/internal/webhook"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveWebhook(handle echo.HandlerFunc, tenantID string, headers map[string]string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("tenant_id")
+	c.SetParamValues(tenantID)
+	_ = handle(c)
+	return rec
+}
+
+func TestGitHubWebhookVerifiesSignature(t *testing.T) {
+	const secret = "gh-secret"
+	const body = `{"zen":"Keep it logically awesome."}`
+	h := NewIntegrationHandler(nil, IntegrationConfig{GitHubWebhookSecrets: map[string]string{"acme": secret}})
+
+	tests := []struct {
+		name      string
+		signature string
+		status    int
+	}{
+		{"valid", webhook.Sign(secret, []byte(body)), http.StatusOK},
+		{"wrong secret", webhook.Sign("other", []byte(body)), http.StatusUnauthorized},
+		{"missing", "", http.StatusUnauthorized},
+		{"malformed", "sha256=zz", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveWebhook(h.GitHubWebhook, "acme", map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": tt.signature,
+			}, body)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestGitHubWebhookRejectsWhenSecretIsNotConfigured(t *testing.T) {
+	h := NewIntegrationHandler(nil, IntegrationConfig{})
+
+	rec := serveWebhook(h.GitHubWebhook, "acme", map[string]string{
+		"X-GitHub-Event":      "ping",
+		"X-Hub-Signature-256": webhook.Sign("", []byte(`{}`)),
+	}, `{}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+type tenantLinkRepo struct {
+	domain.IntegrationRepository
+	tenants []string
+}
+
+func (r *tenantLinkRepo) GetPullRequestLink(ctx context.Context, provider domain.CodeHostProvider, repository string, number int) (*domain.ExternalPullRequest, error) {
+	r.tenants = append(r.tenants, domain.TenantFromContext(ctx))
+	return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "PR link not found")
+}
+
+func TestCodeHostWebhooksRunInPathTenant(t *testing.T) {
+	repo := &tenantLinkRepo{}
+	h := NewIntegrationHandler(usecase.NewIntegrationUseCase(repo, nil, nil, nil, nil, nil), IntegrationConfig{
+		GitHubWebhookSecrets: map[string]string{"acme": "gh-acme", "globex": "gh-globex"},
+		GitLabWebhookTokens:  map[string]string{"acme": "gl-acme"},
+	})
+
+	const githubBody = `{"action":"closed","pull_request":{"number":1},"repository":{"full_name":"acme/api"}}`
+	rec := serveWebhook(h.GitHubWebhook, "globex", map[string]string{
+		"X-GitHub-Event":      "pull_request",
+		"X-Hub-Signature-256": webhook.Sign("gh-globex", []byte(githubBody)),
+	}, githubBody)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serveWebhook(h.GitHubWebhook, "globex", map[string]string{
+		"X-GitHub-Event":      "pull_request",
+		"X-Hub-Signature-256": webhook.Sign("gh-acme", []byte(githubBody)),
+	}, githubBody)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "another tenant's secret does not open this tenant")
+
+	const gitlabBody = `{"object_kind":"merge_request","object_attributes":{"iid":1,"action":"close"},"project":{"path_with_namespace":"acme/api"}}`
+	rec = serveWebhook(h.GitLabWebhook, "acme", map[string]string{
+		"X-Gitlab-Event": "Merge Request Hook",
+		"X-Gitlab-Token": "gl-acme",
+	}, gitlabBody)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	for _, tenantID := range []string{"globex", "", "default"} {
+		rec = serveWebhook(h.GitLabWebhook, tenantID, map[string]string{
+			"X-Gitlab-Event": "Merge Request Hook",
+			"X-Gitlab-Token": "gl-acme",
+		}, gitlabBody)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, tenantID)
+	}
+
+	assert.Equal(t, []string{"globex", "acme"}, repo.tenants)
+}
+
+func TestCodeHostWebhooksWithoutTenantRunInDefaultTenant(t *testing.T) {
+	repo := &tenantLinkRepo{}
+	h := NewIntegrationHandler(usecase.NewIntegrationUseCase(repo, nil, nil, nil, nil, nil), IntegrationConfig{
+		GitHubWebhookSecrets: map[string]string{domain.DefaultTenantID: "gh-default"},
+		GitLabWebhookTokens:  map[string]string{domain.DefaultTenantID: "gl-default"},
+	})
+
+	const githubBody = `{"action":"closed","pull_request":{"number":1},"repository":{"full_name":"acme/api"}}`
+	rec := serveWebhook(h.GitHubWebhook, "", map[string]string{
+		"X-GitHub-Event":      "pull_request",
+		"X-Hub-Signature-256": webhook.Sign("gh-default", []byte(githubBody)),
+	}, githubBody)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	const gitlabBody = `{"object_kind":"merge_request","object_attributes":{"iid":1,"action":"close"},"project":{"path_with_namespace":"acme/api"}}`
+	rec = serveWebhook(h.GitLabWebhook, "", map[string]string{
+		"X-Gitlab-Event": "Merge Request Hook",
+		"X-Gitlab-Token": "gl-default",
+	}, gitlabBody)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.Equal(t, []string{domain.DefaultTenantID, domain.DefaultTenantID}, repo.tenants)
+}
+
+func TestGitHubWebhookIgnoresUnsupportedEvents(t *testing.T) {
+	const secret = "gh-secret"
+	h := NewIntegrationHandler(nil, IntegrationConfig{GitHubWebhookSecrets: map[string]string{"acme": secret}})
+
+	for event, body := range map[string]string{
+		"push":         `{}`,
+		"pull_request": `{"action":"labeled","pull_request":{"number":1}}`,
+	} {
+		rec := serveWebhook(h.GitHubWebhook, "acme", map[string]string{
+			"X-GitHub-Event":      event,
+			"X-Hub-Signature-256": webhook.Sign(secret, []byte(body)),
+		}, body)
+		assert.Equal(t, http.StatusAccepted, rec.Code, event)
+		assert.JSONEq(t, `{"status":"ignored"}`, rec.Body.String(), event)
+	}
+}
+
+func TestGitHubPayloadActionMapping(t *testing.T) {
+	tests := []struct {
+		action string
+		merged bool
+		want   domain.CodeHostAction
+		ok     bool
+	}{
+		{"opened", false, domain.CodeHostActionOpened, true},
+		{"reopened", false, domain.CodeHostActionReopened, true},
+		{"closed", false, domain.CodeHostActionClosed, true},
+		{"closed", true, domain.CodeHostActionMerged, true},
+		{"synchronize", false, "", false},
+		{"edited", false, "", false},
+	}
+	for _, tt := range tests {
+		var payload githubPullRequestPayload
+		payload.Action = tt.action
+		payload.PullRequest.Number = 7
+		payload.PullRequest.Title = "Add cache"
+		payload.PullRequest.Merged = tt.merged
+		payload.PullRequest.User.Login = "octocat"
+		payload.Repository.FullName = "acme/api"
+
+		event, ok := payload.toEvent()
+		require.Equal(t, tt.ok, ok, tt.action)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, &domain.CodeHostPullRequestEvent{
+			Provider:    domain.ProviderGitHub,
+			Action:      tt.want,
+			Repository:  "acme/api",
+			Number:      7,
+			Title:       "Add cache",
+			AuthorLogin: "octocat",
+		}, event, tt.action)
+	}
+}
+
+func TestGitLabWebhookVerifiesToken(t *testing.T) {
+	h := NewIntegrationHandler(nil, IntegrationConfig{GitLabWebhookTokens: map[string]string{"acme": "gl-token"}})
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"valid", "gl-token", http.StatusAccepted},
+		{"wrong", "other", http.StatusUnauthorized},
+		{"missing", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveWebhook(h.GitLabWebhook, "acme", map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": tt.token,
+			}, `{}`)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+
+	rec := serveWebhook(NewIntegrationHandler(nil, IntegrationConfig{}).GitLabWebhook, "acme", map[string]string{
+		"X-Gitlab-Event": "Push Hook",
+		"X-Gitlab-Token": "",
+	}, `{}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestGitLabWebhookIgnoresUnsupportedActions(t *testing.T) {
+	h := NewIntegrationHandler(nil, IntegrationConfig{GitLabWebhookTokens: map[string]string{"acme": "gl-token"}})
+
+	for _, body := range []string{
+		`{"object_kind":"merge_request","object_attributes":{"iid":1,"action":"update"}}`,
+		`{"object_kind":"note","object_attributes":{"iid":1,"action":"open"}}`,
+	} {
+		rec := serveWebhook(h.GitLabWebhook, "acme", map[string]string{
+			"X-Gitlab-Event": "Merge Request Hook",
+			"X-Gitlab-Token": "gl-token",
+		}, body)
+		assert.Equal(t, http.StatusAccepted, rec.Code, body)
+	}
+}
+
+func TestGitLabPayloadActionMapping(t *testing.T) {
+	tests := []struct {
+		action string
+		want   domain.CodeHostAction
+		ok     bool
+	}{
+		{"open", domain.CodeHostActionOpened, true},
+		{"reopen", domain.CodeHostActionReopened, true},
+		{"close", domain.CodeHostActionClosed, true},
+		{"merge", domain.CodeHostActionMerged, true},
+		{"update", "", false},
+		{"approved", "", false},
+	}
+	for _, tt := range tests {
+		var payload gitlabMergeRequestPayload
+		payload.ObjectKind = "merge_request"
+		payload.Project.PathWithNamespace = "acme/api"
+		payload.ObjectAttributes.IID = 3
+		payload.ObjectAttributes.Title = "Add cache"
+		payload.ObjectAttributes.Action = tt.action
+
+		event, ok := payload.toEvent()
+		require.Equal(t, tt.ok, ok, tt.action)
+		if ok {
+			assert.Equal(t, tt.want, event.Action, tt.action)
+			assert.Equal(t, domain.ProviderGitLab, event.Provider)
+			assert.Equal(t, "acme/api", event.Repository)
+			assert.Equal(t, 3, event.Number)
+		}
+	}
+}
+
+func TestGitLabPayloadResolvesAuthorFromAuthorID(t *testing.T) {
+	var payload gitlabMergeRequestPayload
+	payload.ObjectAttributes.Action = "open"
+	payload.ObjectAttributes.AuthorID = 42
+	payload.User.ID = 42
+	payload.User.Username = "alice"
+
+	event, ok := payload.toEvent()
+	require.True(t, ok)
+	assert.Equal(t, "alice", event.AuthorLogin)
+	assert.Equal(t, int64(42), event.AuthorExternalID)
+
+	payload.User.ID = 7
+	payload.User.Username = "release-bot"
+
+	event, ok = payload.toEvent()
+	require.True(t, ok)
+	assert.Empty(t, event.AuthorLogin)
+	assert.Equal(t, int64(42), event.AuthorExternalID)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"avitotest/internal/domain"
+	"avitotest/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OrgUnitHandler struct {
+	orgUnitUseCase *usecase.OrgUnitUseCase
+}
+
+func NewOrgUnitHandler(orgUnitUseCase *usecase.OrgUnitUseCase) *OrgUnitHandler {
+	return &OrgUnitHandler{
+		orgUnitUseCase: orgUnitUseCase,
+	}
+}
+
+func (h *OrgUnitHandler) CreateUnit(c echo.Context) error {
+	var req struct {
+		UnitID   string  `json:"unit_id"`
+		Name     string  `json:"name"`
+		ParentID *string `json:"parent_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	unit, err := h.orgUnitUseCase.CreateUnit(c.Request().Context(), &domain.OrgUnit{
+		UnitID:   req.UnitID,
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 201, map[string]interface{}{
+		"org_unit": unit,
+	})
+}
+
+func (h *OrgUnitHandler) GetUnit(c echo.Context) error {
+	unitID := c.QueryParam("unit_id")
+	if unitID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "unit_id is required"), 400)
+	}
+
+	unit, err := h.orgUnitUseCase.GetUnit(c.Request().Context(), unitID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"org_unit": unit,
+	})
+}
+
+func (h *OrgUnitHandler) ListUnits(c echo.Context) error {
+	units, err := h.orgUnitUseCase.ListUnits(c.Request().Context())
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"org_units": units,
+	})
+}
+
+func (h *OrgUnitHandler) UpdateUnit(c echo.Context) error {
+	var req struct {
+		UnitID   string  `json:"unit_id"`
+		Name     string  `json:"name"`
+		ParentID *string `json:"parent_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	unit, err := h.orgUnitUseCase.UpdateUnit(c.Request().Context(), req.UnitID, req.Name, req.ParentID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"org_unit": unit,
+	})
+}
+
+func (h *OrgUnitHandler) DeleteUnit(c echo.Context) error {
+	var req struct {
+		UnitID string `json:"unit_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	if err := h.orgUnitUseCase.DeleteUnit(c.Request().Context(), req.UnitID); err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"unit_id": req.UnitID,
+	})
+}
+
+func (h *OrgUnitHandler) SetTeamUnit(c echo.Context) error {
+	var req struct {
+		TeamName string  `json:"team_name"`
+		UnitID   *string `json:"unit_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	if err := h.orgUnitUseCase.SetTeamUnit(c.Request().Context(), req.TeamName, req.UnitID); err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"team_name": req.TeamName,
+		"unit_id":   req.UnitID,
+	})
+}
+
+func (h *OrgUnitHandler) GetStats(c echo.Context) error {
+	unitID := c.QueryParam("unit_id")
+	if unitID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "unit_id is required"), 400)
+	}
+
+	stats, err := h.orgUnitUseCase.GetStats(c.Request().Context(), unitID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, stats)
+}
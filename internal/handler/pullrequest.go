@@ -17,24 +17,66 @@ func NewPullRequestHandler(prUseCase *usecase.PullRequestUseCase) *PullRequestHa
 	}
 }
 
-func (h *PullRequestHandler) CreatePullRequest(c echo.Context) error {
-	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+type createPullRequestRequest struct {
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	CoAuthorIDs     []string `json:"co_author_ids"`
+	ReviewTeam      string   `json:"review_team"`
+	Repository      string   `json:"repository"`
+	ChangedFiles    []string `json:"changed_files"`
+	Labels          []string `json:"labels"`
+}
+
+func (r createPullRequestRequest) input() usecase.CreatePullRequestInput {
+	return usecase.CreatePullRequestInput{
+		PullRequestID:   r.PullRequestID,
+		PullRequestName: r.PullRequestName,
+		AuthorID:        r.AuthorID,
+		CoAuthorIDs:     r.CoAuthorIDs,
+		ReviewTeam:      r.ReviewTeam,
+		Repository:      r.Repository,
+		ChangedFiles:    r.ChangedFiles,
+		Labels:          r.Labels,
 	}
+}
 
+func (h *PullRequestHandler) CreatePullRequest(c echo.Context) error {
+	var req createPullRequestRequest
 	if err := c.Bind(&req); err != nil {
 		return WriteError(c, err, 400)
 	}
 
-	pr, err := h.prUseCase.CreatePullRequest(c.Request().Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.prUseCase.CreatePullRequest(c.Request().Context(), req.input())
 	if err != nil {
 		return WriteError(c, err, 0)
 	}
 
-	return WriteJSON(c, 201, map[string]interface{}{
+	response := map[string]interface{}{
 		"pr": pr,
+	}
+	if excluded := pr.Assignment.RemovedBy("exclusion"); len(excluded) > 0 {
+		response["excluded_candidates"] = excluded
+	}
+	return WriteJSON(c, 201, response)
+}
+
+func (h *PullRequestHandler) PreviewAssignment(c echo.Context) error {
+	var req struct {
+		createPullRequestRequest
+		Seed *int64 `json:"seed"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	preview, err := h.prUseCase.PreviewAssignment(c.Request().Context(), req.input(), req.Seed)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"preview": preview,
 	})
 }
 
@@ -57,6 +99,43 @@ func (h *PullRequestHandler) MergePullRequest(c echo.Context) error {
 	})
 }
 
+func (h *PullRequestHandler) SubmitVerdict(c echo.Context) error {
+	var req struct {
+		PullRequestID string               `json:"pull_request_id"`
+		UserID        string               `json:"user_id"`
+		Verdict       domain.ReviewVerdict `json:"verdict"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	pr, err := h.prUseCase.SubmitVerdict(c.Request().Context(), req.PullRequestID, req.UserID, req.Verdict)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+func (h *PullRequestHandler) GetPullRequest(c echo.Context) error {
+	prID := c.QueryParam("pull_request_id")
+	if prID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "pull_request_id is required"), 400)
+	}
+
+	pr, err := h.prUseCase.GetPullRequest(c.Request().Context(), prID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
 func (h *PullRequestHandler) ReassignReviewer(c echo.Context) error {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
@@ -81,8 +160,12 @@ func (h *PullRequestHandler) ReassignReviewer(c echo.Context) error {
 		return WriteError(c, err, 0)
 	}
 
-	return WriteJSON(c, 200, map[string]interface{}{
+	response := map[string]interface{}{
 		"pr":          pr,
 		"replaced_by": newReviewerID,
-	})
+	}
+	if excluded := pr.Assignment.RemovedBy("exclusion"); len(excluded) > 0 {
+		response["excluded_candidates"] = excluded
+	}
+	return WriteJSON(c, 200, response)
 }
@@ -32,10 +32,12 @@ func WriteError(c echo.Context, err error, statusCode int) error {
 		switch domainErr.Code {
 		case domain.ErrorCodeNotFound:
 			statusCode = http.StatusNotFound
-		case domain.ErrorCodeTeamExists, domain.ErrorCodePRExists:
+		case domain.ErrorCodeTeamExists, domain.ErrorCodePRExists, domain.ErrorCodeOrgUnitExists:
 			statusCode = http.StatusConflict
-		case domain.ErrorCodePRMerged, domain.ErrorCodeNotAssigned, domain.ErrorCodeNoCandidate:
+		case domain.ErrorCodePRMerged, domain.ErrorCodePRClosed, domain.ErrorCodeNotAssigned, domain.ErrorCodeNoCandidate:
 			statusCode = http.StatusConflict
+		case domain.ErrorCodeUnauthorized:
+			statusCode = http.StatusUnauthorized
 		default:
 			statusCode = http.StatusBadRequest
 		}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"avitotest/internal/domain"
+	"avitotest/internal/stream"
 	"avitotest/internal/usecase"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -12,6 +15,13 @@ type Router struct {
 	teamHandler        *TeamHandler
 	userHandler        *UserHandler
 	pullRequestHandler *PullRequestHandler
+	webhookHandler     *WebhookHandler
+	integrationHandler *IntegrationHandler
+	codeOwnersHandler  *CodeOwnersHandler
+	exclusionHandler   *ExclusionHandler
+	orgUnitHandler     *OrgUnitHandler
+	streamHandler      *StreamHandler
+	tenantTokens       map[string]string
 	logger             *slog.Logger
 }
 
@@ -19,12 +29,27 @@ func NewRouter(
 	teamUseCase *usecase.TeamUseCase,
 	userUseCase *usecase.UserUseCase,
 	prUseCase *usecase.PullRequestUseCase,
+	webhookUseCase *usecase.WebhookUseCase,
+	integrationUseCase *usecase.IntegrationUseCase,
+	integrationCfg IntegrationConfig,
+	codeOwnersUseCase *usecase.CodeOwnersUseCase,
+	exclusionUseCase *usecase.ExclusionUseCase,
+	orgUnitUseCase *usecase.OrgUnitUseCase,
+	streamHub *stream.Hub,
+	tenantTokens map[string]string,
 	logger *slog.Logger,
 ) *Router {
 	return &Router{
 		teamHandler:        NewTeamHandler(teamUseCase),
 		userHandler:        NewUserHandler(userUseCase, prUseCase),
 		pullRequestHandler: NewPullRequestHandler(prUseCase),
+		webhookHandler:     NewWebhookHandler(webhookUseCase),
+		integrationHandler: NewIntegrationHandler(integrationUseCase, integrationCfg),
+		codeOwnersHandler:  NewCodeOwnersHandler(codeOwnersUseCase),
+		exclusionHandler:   NewExclusionHandler(exclusionUseCase),
+		orgUnitHandler:     NewOrgUnitHandler(orgUnitUseCase),
+		streamHandler:      NewStreamHandler(streamHub),
+		tenantTokens:       tenantTokens,
 		logger:             logger,
 	}
 }
@@ -32,16 +57,72 @@ func (r *Router) SetupRoutes() *echo.Echo {
 	e := echo.New()
 
 	e.Use(r.loggingMiddleware())
+	e.Use(r.tenantMiddleware())
 
 	e.POST("/team/add", r.teamHandler.CreateTeam)
 	e.GET("/team/get", r.teamHandler.GetTeam)
+	e.POST("/team/addMembers", r.teamHandler.AddMembers)
+	e.POST("/team/removeMembers", r.teamHandler.RemoveMembers)
+	e.POST("/team/rename", r.teamHandler.RenameTeam)
+	e.POST("/team/delete", r.teamHandler.DeleteTeam)
+	e.GET("/team/policy", r.teamHandler.GetPolicy)
+	e.POST("/team/setPolicy", r.teamHandler.SetPolicy)
+	e.GET("/team/chat", r.teamHandler.GetChatChannel)
+	e.POST("/team/setChat", r.teamHandler.SetChatChannel)
+	e.POST("/team/setRole", r.teamHandler.SetMemberRole)
 
+	e.POST("/users/upsert", r.userHandler.UpsertUser)
+	e.GET("/users/get", r.userHandler.GetUser)
+	e.GET("/users/list", r.userHandler.ListUsers)
+	e.POST("/users/moveTeam", r.userHandler.MoveTeam)
+	e.POST("/users/archive", r.userHandler.ArchiveUser)
 	e.POST("/users/setIsActive", r.userHandler.SetIsActive)
+	e.POST("/users/setSeniority", r.userHandler.SetSeniority)
 	e.GET("/users/getReview", r.userHandler.GetReviewPullRequests)
+	e.GET("/users/tags", r.userHandler.GetTags)
+	e.POST("/users/tags/set", r.userHandler.SetTags)
+	e.POST("/users/tags/add", r.userHandler.AddTags)
+	e.POST("/users/tags/remove", r.userHandler.RemoveTags)
 
 	e.POST("/pullRequest/create", r.pullRequestHandler.CreatePullRequest)
+	e.POST("/pullRequest/previewAssignment", r.pullRequestHandler.PreviewAssignment)
+	e.GET("/pullRequest/get", r.pullRequestHandler.GetPullRequest)
 	e.POST("/pullRequest/merge", r.pullRequestHandler.MergePullRequest)
 	e.POST("/pullRequest/reassign", r.pullRequestHandler.ReassignReviewer)
+	e.POST("/pullRequest/submitVerdict", r.pullRequestHandler.SubmitVerdict)
+
+	e.POST("/exclusions/add", r.exclusionHandler.AddExclusion)
+	e.POST("/exclusions/delete", r.exclusionHandler.DeleteExclusion)
+	e.GET("/exclusions/list", r.exclusionHandler.ListExclusions)
+
+	e.POST("/orgUnits/add", r.orgUnitHandler.CreateUnit)
+	e.GET("/orgUnits/get", r.orgUnitHandler.GetUnit)
+	e.GET("/orgUnits/list", r.orgUnitHandler.ListUnits)
+	e.POST("/orgUnits/update", r.orgUnitHandler.UpdateUnit)
+	e.POST("/orgUnits/delete", r.orgUnitHandler.DeleteUnit)
+	e.POST("/orgUnits/setTeam", r.orgUnitHandler.SetTeamUnit)
+	e.GET("/orgUnits/stats", r.orgUnitHandler.GetStats)
+
+	e.POST("/codeowners/set", r.codeOwnersHandler.SetRuleset)
+	e.GET("/codeowners/get", r.codeOwnersHandler.GetRuleset)
+
+	e.POST("/webhooks/add", r.webhookHandler.CreateSubscription)
+	e.GET("/webhooks/get", r.webhookHandler.GetSubscription)
+	e.GET("/webhooks/list", r.webhookHandler.ListSubscriptions)
+	e.POST("/webhooks/update", r.webhookHandler.UpdateSubscription)
+	e.POST("/webhooks/delete", r.webhookHandler.DeleteSubscription)
+	e.GET("/webhooks/deliveries", r.webhookHandler.ListDeliveries)
+	e.POST("/webhooks/redeliver", r.webhookHandler.Redeliver)
+
+	e.POST("/integrations/github/webhook", r.integrationHandler.GitHubWebhook)
+	e.POST("/integrations/gitlab/webhook", r.integrationHandler.GitLabWebhook)
+	e.POST("/integrations/github/webhook/:tenant_id", r.integrationHandler.GitHubWebhook)
+	e.POST("/integrations/gitlab/webhook/:tenant_id", r.integrationHandler.GitLabWebhook)
+	e.POST("/integrations/identities/link", r.integrationHandler.LinkIdentity)
+	e.POST("/integrations/identities/unlink", r.integrationHandler.UnlinkIdentity)
+	e.GET("/integrations/identities", r.integrationHandler.ListIdentities)
+
+	e.GET("/events/stream", r.streamHandler.Stream)
 
 	e.GET("/health", func(c echo.Context) error {
 		return c.String(200, "OK")
@@ -75,3 +156,36 @@ func (r *Router) loggingMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+var publicRoutes = map[string]struct{}{
+	"/health":                                 {},
+	"/integrations/github/webhook":            {},
+	"/integrations/gitlab/webhook":            {},
+	"/integrations/github/webhook/:tenant_id": {},
+	"/integrations/gitlab/webhook/:tenant_id": {},
+}
+
+func (r *Router) tenantMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := publicRoutes[c.Path()]; ok {
+				return next(c)
+			}
+
+			header := c.Request().Header.Get("Authorization")
+			if header == "" {
+				return WriteError(c, domain.NewDomainError(domain.ErrorCodeUnauthorized, "missing token"), 0)
+			}
+
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			tenantID, known := r.tenantTokens[strings.TrimSpace(token)]
+			if !ok || !known {
+				return WriteError(c, domain.NewDomainError(domain.ErrorCodeUnauthorized, "invalid token"), 0)
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(domain.WithTenant(req.Context(), tenantID)))
+			return next(c)
+		}
+	}
+}
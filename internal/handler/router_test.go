@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTenantTestServer() *echo.Echo {
+	r := &Router{
+		tenantTokens: map[string]string{"acme-token": "acme"},
+		logger:       slog.New(slog.DiscardHandler),
+	}
+	e := echo.New()
+	e.Use(r.tenantMiddleware())
+	tenant := func(c echo.Context) error {
+		return c.String(http.StatusOK, domain.TenantFromContext(c.Request().Context()))
+	}
+	e.GET("/health", tenant)
+	e.GET("/users/get", tenant)
+	e.POST("/integrations/github/webhook", tenant)
+	e.POST("/integrations/gitlab/webhook", tenant)
+	e.POST("/integrations/github/webhook/:tenant_id", tenant)
+	e.POST("/integrations/gitlab/webhook/:tenant_id", tenant)
+	return e
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	e := newTenantTestServer()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		status int
+		tenant string
+	}{
+		{"known token", http.MethodGet, "/users/get", "Bearer acme-token", http.StatusOK, "acme"},
+		{"missing token", http.MethodGet, "/users/get", "", http.StatusUnauthorized, ""},
+		{"unknown token", http.MethodGet, "/users/get", "Bearer other", http.StatusUnauthorized, ""},
+		{"not bearer", http.MethodGet, "/users/get", "acme-token", http.StatusUnauthorized, ""},
+		{"health", http.MethodGet, "/health", "", http.StatusOK, domain.DefaultTenantID},
+		{"github ingestion", http.MethodPost, "/integrations/github/webhook/acme", "", http.StatusOK, domain.DefaultTenantID},
+		{"gitlab ingestion", http.MethodPost, "/integrations/gitlab/webhook/acme", "", http.StatusOK, domain.DefaultTenantID},
+		{"legacy github ingestion", http.MethodPost, "/integrations/github/webhook", "", http.StatusOK, domain.DefaultTenantID},
+		{"legacy gitlab ingestion", http.MethodPost, "/integrations/gitlab/webhook", "", http.StatusOK, domain.DefaultTenantID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.tenant, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), "UNAUTHORIZED")
+			}
+		})
+	}
+}
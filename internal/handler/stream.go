@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/stream"
+
+	"github.com/labstack/echo/v4"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+type StreamHandler struct {
+	hub *stream.Hub
+}
+
+func NewStreamHandler(hub *stream.Hub) *StreamHandler {
+	return &StreamHandler{
+		hub: hub,
+	}
+}
+
+func (h *StreamHandler) Stream(c echo.Context) error {
+	ctx := c.Request().Context()
+	filter := stream.Filter{
+		TeamName: c.QueryParam("team_name"),
+		UserID:   c.QueryParam("user_id"),
+	}
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+	var afterID int64
+	if lastEventID != "" {
+		parsed, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || parsed < 0 {
+			return WriteError(c, domain.NewDomainError(domain.ErrorCodeValidation, "Last-Event-ID must be a non-negative integer"), 400)
+		}
+		afterID = parsed
+	}
+
+	messages, unsubscribe := h.hub.Subscribe(domain.TenantFromContext(ctx), filter)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	replayed := h.hub.NewReplayedSet()
+	if lastEventID != "" {
+		err := h.hub.Replay(ctx, afterID, filter, func(msg *stream.Message) error {
+			replayed.Add(msg.Event.EventID)
+			return writeStreamEvent(res, msg)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			if replayed.Seen(msg.Event.EventID) {
+				continue
+			}
+			if err := writeStreamEvent(res, msg); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func writeStreamEvent(res *echo.Response, msg *stream.Message) error {
+	data, err := json.Marshal(msg.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream event: %w", err)
+	}
+	if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", msg.Event.EventID, msg.Event.EventType, data); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/stream"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type racingOutboxRepo struct {
+	domain.OutboxRepository
+	hub     *stream.Hub
+	replay  []*domain.Event
+	live    []*domain.Event
+	replays int
+}
+
+func (r *racingOutboxRepo) ListAfter(ctx context.Context, afterID int64, eventTypes []domain.EventType, limit int) ([]*domain.Event, error) {
+	r.replays++
+	if r.replays > 1 {
+		return nil, nil
+	}
+	for _, event := range r.live {
+		r.hub.Handle(ctx, event)
+	}
+	return r.replay, nil
+}
+
+func streamEvent(t *testing.T, eventID int64) *domain.Event {
+	event, err := domain.NewEvent(domain.EventPRCreated, "pr-1", domain.PRStatusChangedPayload{
+		PullRequest: &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", ReviewTeam: "backend"},
+	})
+	require.NoError(t, err)
+	event.EventID = eventID
+	event.TenantID = domain.DefaultTenantID
+	return event
+}
+
+func TestStreamSendsLateCommittedEventsAfterReplay(t *testing.T) {
+	outbox := &racingOutboxRepo{}
+	hub := stream.NewHub(nil, nil, outbox,
+		stream.HubConfig{QueueSize: 10, SubscriberBuffer: 10, ReplayPageSize: 10},
+		slog.New(slog.DiscardHandler),
+	)
+	outbox.hub = hub
+	// Event 4 commits after event 5 was replayed; event 5 also reaches the hub live.
+	outbox.replay = []*domain.Event{streamEvent(t, 5)}
+	outbox.live = []*domain.Event{streamEvent(t, 4), streamEvent(t, 5), streamEvent(t, 6)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+
+	e := echo.New()
+	e.GET("/events/stream", NewStreamHandler(hub).Stream)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events/stream", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "3")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	ids := make(chan int64)
+	go func() {
+		defer close(ids)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if id, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+				parsed, _ := strconv.ParseInt(id, 10, 64)
+				ids <- parsed
+			}
+		}
+	}()
+
+	var received []int64
+	for len(received) < 3 {
+		select {
+		case id, ok := <-ids:
+			require.True(t, ok, "stream closed after %v", received)
+			received = append(received, id)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("stream stalled after %v", received)
+		}
+	}
+	assert.Equal(t, []int64{5, 4, 6}, received)
+
+	cancel()
+	_, _ = io.Copy(io.Discard, resp.Body)
+}
@@ -46,3 +46,166 @@ func (h *TeamHandler) GetTeam(c echo.Context) error {
 
 	return WriteJSON(c, 200, team)
 }
+
+func (h *TeamHandler) GetPolicy(c echo.Context) error {
+	teamName := c.QueryParam("team_name")
+	if teamName == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "team_name is required"), 400)
+	}
+
+	policy, err := h.teamUseCase.GetPolicy(c.Request().Context(), teamName)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"policy": policy,
+	})
+}
+
+func (h *TeamHandler) GetChatChannel(c echo.Context) error {
+	teamName := c.QueryParam("team_name")
+	if teamName == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "team_name is required"), 400)
+	}
+
+	channel, err := h.teamUseCase.GetChatChannel(c.Request().Context(), teamName)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"chat": channel,
+	})
+}
+
+func (h *TeamHandler) SetChatChannel(c echo.Context) error {
+	var req domain.TeamChatChannel
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	channel, err := h.teamUseCase.SetChatChannel(c.Request().Context(), &req)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"chat": channel,
+	})
+}
+
+func (h *TeamHandler) SetPolicy(c echo.Context) error {
+	var req domain.TeamPolicy
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	policy, err := h.teamUseCase.SetPolicy(c.Request().Context(), &req)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"policy": policy,
+	})
+}
+
+func (h *TeamHandler) SetMemberRole(c echo.Context) error {
+	var req struct {
+		TeamName string          `json:"team_name"`
+		UserID   string          `json:"user_id"`
+		Role     domain.TeamRole `json:"role"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	user, err := h.teamUseCase.SetMemberRole(c.Request().Context(), req.TeamName, req.UserID, req.Role)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user": user,
+	})
+}
+
+func (h *TeamHandler) AddMembers(c echo.Context) error {
+	var req domain.Team
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	team, err := h.teamUseCase.AddMembers(c.Request().Context(), req.TeamName, req.Members)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"team": team,
+	})
+}
+
+func (h *TeamHandler) RemoveMembers(c echo.Context) error {
+	var req struct {
+		TeamName     string              `json:"team_name"`
+		UserIDs      []string            `json:"user_ids"`
+		ReviewPolicy domain.ReviewPolicy `json:"review_policy"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	released, err := h.teamUseCase.RemoveMembers(c.Request().Context(), req.TeamName, req.UserIDs, req.ReviewPolicy)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"team_name":                req.TeamName,
+		"removed_user_ids":         req.UserIDs,
+		"released_pull_requests":   released.Released,
+		"unassigned_pull_requests": released.Unassigned,
+	})
+}
+
+func (h *TeamHandler) RenameTeam(c echo.Context) error {
+	var req struct {
+		TeamName    string `json:"team_name"`
+		NewTeamName string `json:"new_team_name"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	team, err := h.teamUseCase.RenameTeam(c.Request().Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"team": team,
+	})
+}
+
+func (h *TeamHandler) DeleteTeam(c echo.Context) error {
+	var req struct {
+		TeamName     string              `json:"team_name"`
+		ReviewPolicy domain.ReviewPolicy `json:"review_policy"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	released, err := h.teamUseCase.DeleteTeam(c.Request().Context(), req.TeamName, req.ReviewPolicy)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"team_name":                req.TeamName,
+		"released_pull_requests":   released.Released,
+		"unassigned_pull_requests": released.Unassigned,
+	})
+}
@@ -3,6 +3,9 @@ package handler
 import (
 	"avitotest/internal/domain"
 	"avitotest/internal/usecase"
+	"context"
+	"strconv"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -21,6 +24,135 @@ func NewUserHandler(
 	}
 }
 
+func (h *UserHandler) UpsertUser(c echo.Context) error {
+	var req struct {
+		UserID      string           `json:"user_id"`
+		Username    string           `json:"username"`
+		Email       string           `json:"email"`
+		TeamName    string           `json:"team_name"`
+		IsActive    *bool            `json:"is_active"`
+		Seniority   domain.Seniority `json:"seniority"`
+		Role        domain.TeamRole  `json:"role"`
+		ChatMention string           `json:"chat_mention"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	user, err := h.userUseCase.UpsertUser(c.Request().Context(), usecase.UpsertUserInput{
+		UserID:      req.UserID,
+		Username:    req.Username,
+		Email:       req.Email,
+		TeamName:    req.TeamName,
+		IsActive:    req.IsActive,
+		Seniority:   req.Seniority,
+		Role:        req.Role,
+		ChatMention: req.ChatMention,
+	})
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user": user,
+	})
+}
+
+func (h *UserHandler) GetUser(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "user_id is required"), 400)
+	}
+
+	user, err := h.userUseCase.GetUser(c.Request().Context(), userID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user": user,
+	})
+}
+
+func (h *UserHandler) ListUsers(c echo.Context) error {
+	filter := domain.UserFilter{TeamName: c.QueryParam("team_name")}
+
+	if value := c.QueryParam("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			return WriteError(c, domain.NewDomainError(domain.ErrorCodeValidation, "is_active must be a boolean"), 400)
+		}
+		filter.IsActive = &isActive
+	}
+	for param, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		value := c.QueryParam(param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return WriteError(c, domain.NewDomainError(domain.ErrorCodeValidation, param+" must be an integer"), 400)
+		}
+		*target = n
+	}
+
+	users, total, err := h.userUseCase.ListUsers(c.Request().Context(), filter)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"users": users,
+		"total": total,
+	})
+}
+
+func (h *UserHandler) MoveTeam(c echo.Context) error {
+	var req struct {
+		UserID       string              `json:"user_id"`
+		TeamName     string              `json:"team_name"`
+		ReviewPolicy domain.ReviewPolicy `json:"review_policy"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	user, released, err := h.userUseCase.MoveTeam(c.Request().Context(), req.UserID, req.TeamName, req.ReviewPolicy)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user":                     user,
+		"released_pull_requests":   released.Released,
+		"unassigned_pull_requests": released.Unassigned,
+	})
+}
+
+func (h *UserHandler) ArchiveUser(c echo.Context) error {
+	var req struct {
+		UserID       string              `json:"user_id"`
+		ReviewPolicy domain.ReviewPolicy `json:"review_policy"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	user, released, err := h.userUseCase.ArchiveUser(c.Request().Context(), req.UserID, req.ReviewPolicy)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user":                     user,
+		"released_pull_requests":   released.Released,
+		"unassigned_pull_requests": released.Unassigned,
+	})
+}
+
 func (h *UserHandler) SetIsActive(c echo.Context) error {
 	var req struct {
 		UserID   string `json:"user_id"`
@@ -41,6 +173,26 @@ func (h *UserHandler) SetIsActive(c echo.Context) error {
 	})
 }
 
+func (h *UserHandler) SetSeniority(c echo.Context) error {
+	var req struct {
+		UserID    string           `json:"user_id"`
+		Seniority domain.Seniority `json:"seniority"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	user, err := h.userUseCase.SetSeniority(c.Request().Context(), req.UserID, req.Seniority)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user": user,
+	})
+}
+
 func (h *UserHandler) GetReviewPullRequests(c echo.Context) error {
 	userID := c.QueryParam("user_id")
 	if userID == "" {
@@ -57,3 +209,54 @@ func (h *UserHandler) GetReviewPullRequests(c echo.Context) error {
 		"pull_requests": prs,
 	})
 }
+
+type userTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+func (h *UserHandler) GetTags(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	if userID == "" {
+		return WriteError(c, domain.NewDomainError(domain.ErrorCodeNotFound, "user_id is required"), 400)
+	}
+
+	tags, err := h.userUseCase.GetTags(c.Request().Context(), userID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user_id": userID,
+		"tags":    tags,
+	})
+}
+
+func (h *UserHandler) SetTags(c echo.Context) error {
+	return h.updateTags(c, h.userUseCase.SetTags)
+}
+
+func (h *UserHandler) AddTags(c echo.Context) error {
+	return h.updateTags(c, h.userUseCase.AddTags)
+}
+
+func (h *UserHandler) RemoveTags(c echo.Context) error {
+	return h.updateTags(c, h.userUseCase.RemoveTags)
+}
+
+func (h *UserHandler) updateTags(c echo.Context, update func(ctx context.Context, userID string, tags []string) ([]string, error)) error {
+	var req userTagsRequest
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	tags, err := update(c.Request().Context(), req.UserID, req.Tags)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"user_id": req.UserID,
+		"tags":    tags,
+	})
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"strconv"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookUseCase *usecase.WebhookUseCase
+}
+
+func NewWebhookHandler(webhookUseCase *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+type webhookRequest struct {
+	SubscriptionID int64              `json:"subscription_id"`
+	URL            string             `json:"url"`
+	EventTypes     []domain.EventType `json:"event_types"`
+	Secret         string             `json:"secret"`
+	IsActive       *bool              `json:"is_active"`
+}
+
+func (h *WebhookHandler) CreateSubscription(c echo.Context) error {
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	sub, err := h.webhookUseCase.CreateSubscription(c.Request().Context(), &domain.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 201, map[string]interface{}{
+		"webhook": sub,
+	})
+}
+
+func (h *WebhookHandler) GetSubscription(c echo.Context) error {
+	subscriptionID, err := subscriptionIDParam(c)
+	if err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	sub, err := h.webhookUseCase.GetSubscription(c.Request().Context(), subscriptionID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"webhook": sub,
+	})
+}
+
+func (h *WebhookHandler) ListSubscriptions(c echo.Context) error {
+	subs, err := h.webhookUseCase.ListSubscriptions(c.Request().Context())
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"webhooks": subs,
+	})
+}
+
+func (h *WebhookHandler) UpdateSubscription(c echo.Context) error {
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	sub, err := h.webhookUseCase.UpdateSubscription(c.Request().Context(), &domain.WebhookSubscription{
+		SubscriptionID: req.SubscriptionID,
+		URL:            req.URL,
+		EventTypes:     req.EventTypes,
+		Secret:         req.Secret,
+		IsActive:       isActive,
+	})
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"webhook": sub,
+	})
+}
+
+func (h *WebhookHandler) DeleteSubscription(c echo.Context) error {
+	var req struct {
+		SubscriptionID int64 `json:"subscription_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	if err := h.webhookUseCase.DeleteSubscription(c.Request().Context(), req.SubscriptionID); err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"subscription_id": req.SubscriptionID,
+	})
+}
+
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	subscriptionID, err := subscriptionIDParam(c)
+	if err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(c.Request().Context(), subscriptionID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 200, map[string]interface{}{
+		"subscription_id": subscriptionID,
+		"deliveries":      deliveries,
+	})
+}
+
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	var req struct {
+		SubscriptionID int64 `json:"subscription_id"`
+		EventID        int64 `json:"event_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return WriteError(c, err, 400)
+	}
+
+	delivery, err := h.webhookUseCase.Redeliver(c.Request().Context(), req.SubscriptionID, req.EventID)
+	if err != nil {
+		return WriteError(c, err, 0)
+	}
+
+	return WriteJSON(c, 202, map[string]interface{}{
+		"delivery": delivery,
+	})
+}
+
+func subscriptionIDParam(c echo.Context) (int64, error) {
+	subscriptionID, err := strconv.ParseInt(c.QueryParam("subscription_id"), 10, 64)
+	if err != nil {
+		return 0, domain.NewDomainError(domain.ErrorCodeValidation, "subscription_id must be an integer")
+	}
+	return subscriptionID, nil
+}
@@ -0,0 +1,199 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type ChatConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+}
+
+type chatMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+var chatEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+type ChatNotifier struct {
+	userRepo domain.UserRepository
+	prRepo   domain.PullRequestRepository
+	teamRepo domain.TeamRepository
+	client   *http.Client
+	worker   *jobWorker
+	logger   *slog.Logger
+}
+
+func NewChatNotifier(
+	userRepo domain.UserRepository,
+	prRepo domain.PullRequestRepository,
+	teamRepo domain.TeamRepository,
+	notificationRepo domain.NotificationRepository,
+	cfg ChatConfig,
+	logger *slog.Logger,
+) *ChatNotifier {
+	n := &ChatNotifier{
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		teamRepo: teamRepo,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		logger:   logger,
+	}
+	n.worker = newJobWorker(domain.NotificationChannelChat, notificationRepo, jobConfig{
+		PollInterval: cfg.PollInterval,
+		BatchSize:    cfg.BatchSize,
+		MaxAttempts:  cfg.MaxAttempts,
+		BaseBackoff:  cfg.BaseBackoff,
+		MaxBackoff:   cfg.MaxBackoff,
+		SendTimeout:  cfg.Timeout,
+	}, n.send, logger)
+	return n
+}
+
+func (n *ChatNotifier) Enqueue(ctx context.Context, event *domain.Event) error {
+	switch event.EventType {
+	case domain.EventReviewerAssigned, domain.EventReviewerReassigned, domain.EventPRMerged:
+		return n.worker.enqueue(ctx, event, "")
+	}
+	return nil
+}
+
+func (n *ChatNotifier) Run(ctx context.Context) {
+	n.worker.run(ctx)
+}
+
+func (n *ChatNotifier) send(ctx context.Context, event *domain.Event, _ string) error {
+	pr, text, err := n.compose(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to prepare chat notification: %w", err)
+	}
+	if pr == nil {
+		return nil
+	}
+
+	teamName, err := n.teamName(ctx, pr)
+	if err != nil {
+		return fmt.Errorf("failed to resolve chat team: %w", err)
+	}
+	channel, err := n.teamRepo.GetChatChannel(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to get team chat channel: %w", err)
+	}
+	if channel.WebhookURL == "" {
+		return nil
+	}
+
+	return n.post(ctx, channel.WebhookURL, chatMessage{Channel: channel.Channel, Text: text})
+}
+
+func (n *ChatNotifier) compose(ctx context.Context, event *domain.Event) (*domain.PullRequest, string, error) {
+	switch event.EventType {
+	case domain.EventReviewerAssigned:
+		var payload domain.ReviewerAssignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, "", err
+		}
+		pr, err := n.prRepo.GetByID(ctx, payload.PullRequestID)
+		if err != nil {
+			return nil, "", err
+		}
+		text := fmt.Sprintf(":eyes: %s was assigned to review %s by %s",
+			n.mention(ctx, payload.ReviewerID), pullRequestRef(pr), n.mention(ctx, pr.AuthorID))
+		return pr, text, nil
+	case domain.EventReviewerReassigned:
+		var payload domain.ReviewerReassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, "", err
+		}
+		pr, err := n.prRepo.GetByID(ctx, payload.PullRequestID)
+		if err != nil {
+			return nil, "", err
+		}
+		text := fmt.Sprintf(":arrows_counterclockwise: %s replaced %s as reviewer of %s by %s",
+			n.mention(ctx, payload.NewReviewerID), n.mention(ctx, payload.OldReviewerID), pullRequestRef(pr), n.mention(ctx, pr.AuthorID))
+		return pr, text, nil
+	case domain.EventPRMerged:
+		var payload domain.PRMergedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, "", err
+		}
+		pr := payload.PullRequest
+		if pr == nil {
+			return nil, "", nil
+		}
+		text := fmt.Sprintf(":white_check_mark: %s by %s was merged", pullRequestRef(pr), n.mention(ctx, pr.AuthorID))
+		if len(pr.AssignedReviewers) > 0 {
+			reviewers := make([]string, 0, len(pr.AssignedReviewers))
+			for _, reviewerID := range pr.AssignedReviewers {
+				reviewers = append(reviewers, n.mention(ctx, reviewerID))
+			}
+			text += ", reviewed by " + strings.Join(reviewers, ", ")
+		}
+		return pr, text, nil
+	}
+	return nil, "", nil
+}
+
+func (n *ChatNotifier) mention(ctx context.Context, userID string) string {
+	user, err := n.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return chatEscaper.Replace(userID)
+	}
+	if user.ChatMention != "" {
+		return user.ChatMention
+	}
+	return chatEscaper.Replace(user.Username)
+}
+
+func (n *ChatNotifier) teamName(ctx context.Context, pr *domain.PullRequest) (string, error) {
+	if pr.ReviewTeam != "" || pr.AuthorID == "" {
+		return pr.ReviewTeam, nil
+	}
+	author, err := n.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+	return author.TeamName, nil
+}
+
+func pullRequestRef(pr *domain.PullRequest) string {
+	return fmt.Sprintf("*%s* (`%s`)", chatEscaper.Replace(pr.PullRequestName), chatEscaper.Replace(pr.PullRequestID))
+}
+
+func (n *ChatNotifier) post(ctx context.Context, url string, message chatMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post chat message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("chat webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/notify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chatTeamRepo struct {
+	domain.TeamRepository
+	channels map[string]*domain.TeamChatChannel
+}
+
+func (r *chatTeamRepo) GetChatChannel(ctx context.Context, teamName string) (*domain.TeamChatChannel, error) {
+	if channel, ok := r.channels[teamName]; ok {
+		return channel, nil
+	}
+	return &domain.TeamChatChannel{TeamName: teamName}, nil
+}
+
+type chatPayload struct {
+	Channel string `json:"channel"`
+	Text    string `json:"text"`
+}
+
+func startChatReceiver(t *testing.T, failures int32) (string, <-chan chatPayload) {
+	remainingFailures := &atomic.Int32{}
+	remainingFailures.Store(failures)
+
+	payloads := make(chan chatPayload, 16)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if remainingFailures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload chatPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		payloads <- payload
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver.URL, payloads
+}
+
+func startChatNotifier(t *testing.T, webhookURL string) (*notify.ChatNotifier, *memoryNotificationRepo) {
+	users := &emailUserRepo{users: map[string]*domain.User{
+		"u1": {UserID: "u1", Username: "Alice", TeamName: "backend"},
+		"u2": {UserID: "u2", Username: "Bob", TeamName: "backend", ChatMention: "<@U0BOB>"},
+		"u3": {UserID: "u3", Username: "Carol", TeamName: "backend", ChatMention: "@carol"},
+	}}
+	teams := &chatTeamRepo{channels: map[string]*domain.TeamChatChannel{
+		"backend": {TeamName: "backend", WebhookURL: webhookURL, Channel: "#backend-reviews"},
+	}}
+	jobs := newMemoryNotificationRepo()
+	notifier := notify.NewChatNotifier(
+		users,
+		&emailPullRequestRepo{},
+		teams,
+		jobs,
+		notify.ChatConfig{
+			PollInterval: 10 * time.Millisecond,
+			BatchSize:    10,
+			MaxAttempts:  3,
+			BaseBackoff:  10 * time.Millisecond,
+			MaxBackoff:   50 * time.Millisecond,
+			Timeout:      time.Second,
+		},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go notifier.Run(ctx)
+	return notifier, jobs
+}
+
+func receiveChat(t *testing.T, payloads <-chan chatPayload) chatPayload {
+	select {
+	case payload := <-payloads:
+		return payload
+	case <-time.After(2 * time.Second):
+		t.Fatal("chat message was not posted")
+		return chatPayload{}
+	}
+}
+
+func TestChatNotifierAssignmentMentionsReviewer(t *testing.T) {
+	url, payloads := startChatReceiver(t, 0)
+	notifier, jobs := startChatNotifier(t, url)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u2",
+	}))
+
+	payload := receiveChat(t, payloads)
+	assert.Equal(t, "#backend-reviews", payload.Channel)
+	assert.Equal(t, ":eyes: <@U0BOB> was assigned to review *Add &lt;search&gt;* (`pr-1`) by Alice", payload.Text)
+}
+
+func TestChatNotifierReassignmentAndMerge(t *testing.T) {
+	url, payloads := startChatReceiver(t, 0)
+	notifier, jobs := startChatNotifier(t, url)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventReviewerReassigned, domain.ReviewerReassignedPayload{
+		PullRequestID: "pr-1",
+		OldReviewerID: "u2",
+		NewReviewerID: "u3",
+	}))
+	assert.Equal(t, ":arrows_counterclockwise: @carol replaced <@U0BOB> as reviewer of *Add &lt;search&gt;* (`pr-1`) by Alice",
+		receiveChat(t, payloads).Text)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventPRMerged, domain.PRMergedPayload{
+		PullRequest: &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusMerged,
+			AssignedReviewers: []string{"u3", "u9"},
+		},
+	}))
+	assert.Equal(t, ":white_check_mark: *Add search* (`pr-1`) by Alice was merged, reviewed by @carol, u9",
+		receiveChat(t, payloads).Text)
+}
+
+func TestChatNotifierSkipsTeamsWithoutChannel(t *testing.T) {
+	url, payloads := startChatReceiver(t, 0)
+	notifier, jobs := startChatNotifier(t, url)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventPRMerged, domain.PRMergedPayload{
+		PullRequest: &domain.PullRequest{
+			PullRequestID:   "pr-2",
+			PullRequestName: "Update docs",
+			AuthorID:        "u1",
+			ReviewTeam:      "docs",
+			Status:          domain.PRStatusMerged,
+		},
+	}))
+
+	select {
+	case payload := <-payloads:
+		t.Fatalf("unexpected chat message %q", payload.Text)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestChatNotifierRetriesFailedPost(t *testing.T) {
+	url, payloads := startChatReceiver(t, 2)
+	notifier, jobs := startChatNotifier(t, url)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u3",
+	}))
+
+	require.Contains(t, receiveChat(t, payloads).Text, "@carol was assigned")
+}
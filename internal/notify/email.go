@@ -0,0 +1,225 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type EmailConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+}
+
+type emailNotice struct {
+	userID string
+	kind   emailKind
+	pr     *domain.PullRequest
+}
+
+type outgoingEmail struct {
+	userID  string
+	to      string
+	subject string
+	text    string
+	html    string
+}
+
+type EmailNotifier struct {
+	userRepo domain.UserRepository
+	prRepo   domain.PullRequestRepository
+	smtp     *SMTPNotifier
+	worker   *jobWorker
+	logger   *slog.Logger
+}
+
+func NewEmailNotifier(
+	userRepo domain.UserRepository,
+	prRepo domain.PullRequestRepository,
+	notificationRepo domain.NotificationRepository,
+	smtp *SMTPNotifier,
+	cfg EmailConfig,
+	logger *slog.Logger,
+) *EmailNotifier {
+	n := &EmailNotifier{
+		userRepo: userRepo,
+		prRepo:   prRepo,
+		smtp:     smtp,
+		logger:   logger,
+	}
+	n.worker = newJobWorker(domain.NotificationChannelEmail, notificationRepo, jobConfig{
+		PollInterval: cfg.PollInterval,
+		BatchSize:    cfg.BatchSize,
+		MaxAttempts:  cfg.MaxAttempts,
+		BaseBackoff:  cfg.BaseBackoff,
+		MaxBackoff:   cfg.MaxBackoff,
+		SendTimeout:  cfg.Timeout,
+	}, n.send, logger)
+	return n
+}
+
+func (n *EmailNotifier) Enqueue(ctx context.Context, event *domain.Event) error {
+	recipients, err := emailRecipients(event)
+	if err != nil {
+		n.logger.Error("failed to decode event payload", "event_id", event.EventID, "error", err)
+		return nil
+	}
+	return n.worker.enqueue(ctx, event, recipients...)
+}
+
+func (n *EmailNotifier) Run(ctx context.Context) {
+	n.worker.run(ctx)
+}
+
+func emailRecipients(event *domain.Event) ([]string, error) {
+	switch event.EventType {
+	case domain.EventReviewerAssigned:
+		var payload domain.ReviewerAssignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return []string{payload.ReviewerID}, nil
+	case domain.EventReviewerReassigned:
+		var payload domain.ReviewerReassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return []string{payload.OldReviewerID, payload.NewReviewerID}, nil
+	case domain.EventReviewerUnassigned:
+		var payload domain.ReviewerUnassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return []string{payload.ReviewerID}, nil
+	case domain.EventPRMerged:
+		var payload domain.PRMergedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		if payload.PullRequest == nil {
+			return nil, nil
+		}
+		return payload.PullRequest.AssignedReviewers, nil
+	}
+	return nil, nil
+}
+
+func (n *EmailNotifier) send(ctx context.Context, event *domain.Event, userID string) error {
+	notices, err := n.notices(ctx, event)
+	if err != nil {
+		return fmt.Errorf("failed to prepare email notification: %w", err)
+	}
+
+	for _, notice := range notices {
+		if notice.userID != userID {
+			continue
+		}
+		email, err := n.compose(ctx, notice)
+		if errors.Is(err, errNoRecipient) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to compose email notification: %w", err)
+		}
+		return email.send(ctx, n.smtp)
+	}
+	return nil
+}
+
+func (n *EmailNotifier) notices(ctx context.Context, event *domain.Event) ([]emailNotice, error) {
+	switch event.EventType {
+	case domain.EventReviewerAssigned:
+		var payload domain.ReviewerAssignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		pr, err := n.prRepo.GetByID(ctx, payload.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		return []emailNotice{{userID: payload.ReviewerID, kind: emailAssigned, pr: pr}}, nil
+	case domain.EventReviewerReassigned:
+		var payload domain.ReviewerReassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		pr, err := n.prRepo.GetByID(ctx, payload.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		return []emailNotice{
+			{userID: payload.OldReviewerID, kind: emailUnassigned, pr: pr},
+			{userID: payload.NewReviewerID, kind: emailAssigned, pr: pr},
+		}, nil
+	case domain.EventReviewerUnassigned:
+		var payload domain.ReviewerUnassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		pr, err := n.prRepo.GetByID(ctx, payload.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		return []emailNotice{{userID: payload.ReviewerID, kind: emailUnassigned, pr: pr}}, nil
+	case domain.EventPRMerged:
+		var payload domain.PRMergedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		if payload.PullRequest == nil {
+			return nil, nil
+		}
+		notices := make([]emailNotice, 0, len(payload.PullRequest.AssignedReviewers))
+		for _, reviewerID := range payload.PullRequest.AssignedReviewers {
+			notices = append(notices, emailNotice{userID: reviewerID, kind: emailMerged, pr: payload.PullRequest})
+		}
+		return notices, nil
+	}
+	return nil, nil
+}
+
+func (n *EmailNotifier) compose(ctx context.Context, notice emailNotice) (*outgoingEmail, error) {
+	user, err := n.userRepo.GetByID(ctx, notice.userID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.smtp.recipient(user.UserID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, text, html, err := emailTemplates[notice.kind].render(emailData{
+		Username:        user.Username,
+		PullRequestID:   notice.pr.PullRequestID,
+		PullRequestName: notice.pr.PullRequestName,
+		AuthorID:        notice.pr.AuthorID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", notice.kind, err)
+	}
+
+	return &outgoingEmail{
+		userID:  user.UserID,
+		to:      to,
+		subject: subject,
+		text:    text,
+		html:    html,
+	}, nil
+}
+
+func (e *outgoingEmail) send(ctx context.Context, smtp *SMTPNotifier) error {
+	contentType, body, err := alternativeBody(e.text, e.html)
+	if err != nil {
+		return err
+	}
+	return smtp.send(ctx, e.to, e.subject, contentType, body)
+}
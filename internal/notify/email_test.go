@@ -0,0 +1,202 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/notify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type emailUserRepo struct {
+	domain.UserRepository
+	users map[string]*domain.User
+}
+
+func (r *emailUserRepo) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	if user, ok := r.users[userID]; ok {
+		return user, nil
+	}
+	return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+}
+
+type emailPullRequestRepo struct {
+	domain.PullRequestRepository
+}
+
+func (r *emailPullRequestRepo) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return &domain.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   "Add <search>",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u3"},
+	}, nil
+}
+
+func newEmailNotifier(addr string, jobs *memoryNotificationRepo) *notify.EmailNotifier {
+	users := &emailUserRepo{users: map[string]*domain.User{
+		"u2": {UserID: "u2", Username: "Bob", Email: "bob@example.com"},
+		"u3": {UserID: "u3", Username: "Carol", Email: "carol@example.com"},
+		"u4": {UserID: "u4", Username: "Dave"},
+	}}
+	notifier := notify.NewEmailNotifier(
+		users,
+		&emailPullRequestRepo{},
+		jobs,
+		notify.NewSMTPNotifier(notify.SMTPConfig{Addr: addr, From: "reviews@example.com"}),
+		notify.EmailConfig{
+			PollInterval: 10 * time.Millisecond,
+			BatchSize:    10,
+			MaxAttempts:  3,
+			BaseBackoff:  10 * time.Millisecond,
+			MaxBackoff:   50 * time.Millisecond,
+			Timeout:      time.Second,
+		},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	return notifier
+}
+
+func startEmailNotifier(t *testing.T, addr string) (*notify.EmailNotifier, *memoryNotificationRepo) {
+	jobs := newMemoryNotificationRepo()
+	notifier := newEmailNotifier(addr, jobs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go notifier.Run(ctx)
+	return notifier, jobs
+}
+
+func testEvent(t *testing.T, eventType domain.EventType, payload interface{}) *domain.Event {
+	event, err := domain.NewEvent(eventType, "pr-1", payload)
+	require.NoError(t, err)
+	return event
+}
+
+func receiveMail(t *testing.T, mails <-chan receivedMail) receivedMail {
+	select {
+	case mail := <-mails:
+		return mail
+	case <-time.After(2 * time.Second):
+		t.Fatal("email was not delivered")
+		return receivedMail{}
+	}
+}
+
+func TestEmailNotifierReassignment(t *testing.T) {
+	addr, mails := startSMTPStandIn(t)
+	notifier, jobs := startEmailNotifier(t, addr)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventReviewerReassigned, domain.ReviewerReassignedPayload{
+		PullRequestID: "pr-1",
+		OldReviewerID: "u2",
+		NewReviewerID: "u3",
+	}))
+
+	unassigned := receiveMail(t, mails)
+	assert.Equal(t, []string{"bob@example.com"}, unassigned.to)
+	assert.Contains(t, unassigned.data, "Subject: Review no longer needed: Add <search>")
+
+	assigned := receiveMail(t, mails)
+	assert.Equal(t, []string{"carol@example.com"}, assigned.to)
+	assert.Contains(t, assigned.data, "Subject: Review requested: Add <search>")
+	assert.Contains(t, assigned.data, "Content-Type: multipart/alternative; boundary=")
+	assert.Contains(t, assigned.data, `You have been assigned to review pull request pr-1 "Add <search>" by u1.`)
+	assert.Contains(t, assigned.data, "<b>pr-1</b> &ldquo;Add &lt;search&gt;&rdquo;")
+}
+
+func TestEmailNotifierMergedSkipsUsersWithoutEmail(t *testing.T) {
+	addr, mails := startSMTPStandIn(t)
+	notifier, jobs := startEmailNotifier(t, addr)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventPRMerged, domain.PRMergedPayload{
+		PullRequest: &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add search",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusMerged,
+			AssignedReviewers: []string{"u4", "u2"},
+		},
+	}))
+
+	merged := receiveMail(t, mails)
+	assert.Equal(t, []string{"bob@example.com"}, merged.to)
+	assert.Contains(t, merged.data, "Subject: Merged: Add search")
+
+	select {
+	case mail := <-mails:
+		t.Fatalf("unexpected email to %v", mail.to)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEmailNotifierRetriesFailedDelivery(t *testing.T) {
+	addr, mails := startFlakySMTPStandIn(t, 2)
+	notifier, jobs := startEmailNotifier(t, addr)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u3",
+	}))
+
+	assigned := receiveMail(t, mails)
+	assert.Equal(t, []string{"carol@example.com"}, assigned.to)
+}
+
+func TestEmailNotifierIgnoresUnrelatedEvents(t *testing.T) {
+	addr, mails := startSMTPStandIn(t)
+	notifier, jobs := startEmailNotifier(t, addr)
+
+	payload, err := json.Marshal(domain.UserActivityChangedPayload{UserID: "u2"})
+	require.NoError(t, err)
+	jobs.publish(t, notifier.Enqueue, &domain.Event{EventType: domain.EventUserActivityChanged, Payload: payload})
+	assert.Empty(t, jobs.snapshot())
+
+	select {
+	case mail := <-mails:
+		t.Fatalf("unexpected email to %v", mail.to)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEmailNotifierDeliversJobsLeftByPreviousRun(t *testing.T) {
+	addr, mails := startSMTPStandIn(t)
+	jobs := newMemoryNotificationRepo()
+
+	stopped := newEmailNotifier(addr, jobs)
+	jobs.publish(t, stopped.Enqueue, testEvent(t, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u3",
+	}))
+
+	restarted := newEmailNotifier(addr, jobs)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go restarted.Run(ctx)
+
+	assigned := receiveMail(t, mails)
+	assert.Equal(t, []string{"carol@example.com"}, assigned.to)
+}
+
+func TestEmailNotifierGivesUpAfterMaxAttempts(t *testing.T) {
+	addr, _ := startFlakySMTPStandIn(t, 10)
+	notifier, jobs := startEmailNotifier(t, addr)
+
+	jobs.publish(t, notifier.Enqueue, testEvent(t, domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u3",
+	}))
+
+	require.Eventually(t, func() bool {
+		job := jobs.snapshot()[0]
+		return job.status == domain.EventStatusDead && job.attempts == 3
+	}, 2*time.Second, 10*time.Millisecond)
+}
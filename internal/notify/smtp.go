@@ -0,0 +1,142 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+// defaultSMTPTimeout bounds a send whose context carries no deadline of its own.
+const defaultSMTPTimeout = 30 * time.Second
+
+var errNoRecipient = errors.New("no email address for user")
+
+type SMTPConfig struct {
+	Addr              string
+	From              string
+	Username          string
+	Password          string
+	RecipientTemplate string
+}
+
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (n *SMTPNotifier) SendDigest(ctx context.Context, digest *domain.ReviewDigest) error {
+	to, err := n.recipient(digest.UserID, digest.Email)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\r\n\r\nYou have %d pull request(s) waiting for your review:\r\n\r\n", digest.Username, len(digest.PullRequests))
+	for _, pr := range digest.PullRequests {
+		fmt.Fprintf(&body, "- %s: %s (author %s)\r\n", pr.PullRequestID, pr.PullRequestName, pr.AuthorID)
+	}
+
+	subject := fmt.Sprintf("%d pull request(s) waiting for your review", len(digest.PullRequests))
+	return n.send(ctx, to, subject, "text/plain; charset=UTF-8", body.String())
+}
+
+func (n *SMTPNotifier) recipient(userID, email string) (string, error) {
+	if email != "" {
+		return email, nil
+	}
+	if n.cfg.RecipientTemplate == "" {
+		return "", fmt.Errorf("%w %s", errNoRecipient, userID)
+	}
+	return strings.ReplaceAll(n.cfg.RecipientTemplate, "{user_id}", userID), nil
+}
+
+func (n *SMTPNotifier) send(ctx context.Context, to, subject, contentType, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s\r\n\r\n", contentType)
+	msg.WriteString(body)
+
+	if err := n.deliver(ctx, to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", to, err)
+	}
+	return nil
+}
+
+func (n *SMTPNotifier) deliver(ctx context.Context, to string, msg []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSMTPTimeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+
+	host, _, err := net.SplitHostPort(n.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid smtp address: %w", err)
+	}
+
+	conn, err := net.DialTimeout("tcp", n.cfg.Addr, time.Until(deadline))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,82 @@
+package notify_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/notify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDigest() *domain.ReviewDigest {
+	return &domain.ReviewDigest{
+		UserID:   "u2",
+		Username: "Bob",
+		TeamName: "backend",
+		PullRequests: []*domain.PullRequestShort{
+			{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen},
+			{PullRequestID: "pr-2", PullRequestName: "Fix login", AuthorID: "u3", Status: domain.PRStatusOpen},
+		},
+		GeneratedAt: time.Now(),
+	}
+}
+
+func TestSMTPNotifierSendsDigest(t *testing.T) {
+	addr, mails := startSMTPStandIn(t)
+	notifier := notify.NewSMTPNotifier(notify.SMTPConfig{
+		Addr:              addr,
+		From:              "reviews@example.com",
+		RecipientTemplate: "{user_id}@example.com",
+	})
+
+	require.NoError(t, notifier.SendDigest(context.Background(), testDigest()))
+
+	select {
+	case mail := <-mails:
+		assert.Equal(t, "reviews@example.com", mail.from)
+		assert.Equal(t, []string{"u2@example.com"}, mail.to)
+		assert.Contains(t, mail.data, "Subject: 2 pull request(s) waiting for your review")
+		assert.Contains(t, mail.data, "pr-1: Add search (author u1)")
+		assert.Contains(t, mail.data, "pr-2: Fix login (author u3)")
+	case <-time.After(time.Second):
+		t.Fatal("digest was not delivered")
+	}
+}
+
+func TestSMTPNotifierRequiresRecipient(t *testing.T) {
+	notifier := notify.NewSMTPNotifier(notify.SMTPConfig{Addr: "127.0.0.1:1", From: "reviews@example.com"})
+	assert.Error(t, notifier.SendDigest(context.Background(), testDigest()))
+}
+
+func TestSMTPNotifierGivesUpWhenContextExpires(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	released := make(chan struct{})
+	t.Cleanup(func() { close(released) })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		<-released
+	}()
+
+	notifier := notify.NewSMTPNotifier(notify.SMTPConfig{
+		Addr:              listener.Addr().String(),
+		From:              "reviews@example.com",
+		RecipientTemplate: "{user_id}@example.com",
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	assert.Error(t, notifier.SendDigest(ctx, testDigest()))
+	assert.Less(t, time.Since(start), time.Second)
+}
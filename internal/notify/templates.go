@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"mime/multipart"
+	"net/textproto"
+	texttemplate "text/template"
+)
+
+type emailKind string
+
+const (
+	emailAssigned   emailKind = "assigned"
+	emailUnassigned emailKind = "unassigned"
+	emailMerged     emailKind = "merged"
+)
+
+type emailData struct {
+	Username        string
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+}
+
+type emailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+var emailTemplates = map[emailKind]emailTemplate{
+	emailAssigned: newEmailTemplate(emailAssigned,
+		`Review requested: {{.PullRequestName}}`,
+		`Hi {{.Username}},
+
+You have been assigned to review pull request {{.PullRequestID}} "{{.PullRequestName}}" by {{.AuthorID}}.
+`,
+		`<p>Hi {{.Username}},</p>
+<p>You have been assigned to review pull request <b>{{.PullRequestID}}</b> &ldquo;{{.PullRequestName}}&rdquo; by {{.AuthorID}}.</p>
+`),
+	emailUnassigned: newEmailTemplate(emailUnassigned,
+		`Review no longer needed: {{.PullRequestName}}`,
+		`Hi {{.Username}},
+
+You are no longer a reviewer of pull request {{.PullRequestID}} "{{.PullRequestName}}" by {{.AuthorID}}.
+`,
+		`<p>Hi {{.Username}},</p>
+<p>You are no longer a reviewer of pull request <b>{{.PullRequestID}}</b> &ldquo;{{.PullRequestName}}&rdquo; by {{.AuthorID}}.</p>
+`),
+	emailMerged: newEmailTemplate(emailMerged,
+		`Merged: {{.PullRequestName}}`,
+		`Hi {{.Username}},
+
+Pull request {{.PullRequestID}} "{{.PullRequestName}}" by {{.AuthorID}} that you were reviewing has been merged.
+`,
+		`<p>Hi {{.Username}},</p>
+<p>Pull request <b>{{.PullRequestID}}</b> &ldquo;{{.PullRequestName}}&rdquo; by {{.AuthorID}} that you were reviewing has been merged.</p>
+`),
+}
+
+func newEmailTemplate(kind emailKind, subject, text, html string) emailTemplate {
+	name := string(kind)
+	return emailTemplate{
+		subject: texttemplate.Must(texttemplate.New(name + ".subject").Parse(subject)),
+		text:    texttemplate.Must(texttemplate.New(name + ".txt").Parse(text)),
+		html:    htmltemplate.Must(htmltemplate.New(name + ".html").Parse(html)),
+	}
+}
+
+func (t emailTemplate) render(data emailData) (subject, text, html string, err error) {
+	var subjectBuf, textBuf, htmlBuf bytes.Buffer
+	if err := t.subject.Execute(&subjectBuf, data); err != nil {
+		return "", "", "", err
+	}
+	if err := t.text.Execute(&textBuf, data); err != nil {
+		return "", "", "", err
+	}
+	if err := t.html.Execute(&htmlBuf, data); err != nil {
+		return "", "", "", err
+	}
+	return subjectBuf.String(), textBuf.String(), htmlBuf.String(), nil
+}
+
+func alternativeBody(text, html string) (contentType, body string, err error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	}
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := partWriter.Write([]byte(part.body)); err != nil {
+			return "", "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+	return "multipart/alternative; boundary=" + writer.Boundary(), buf.String(), nil
+}
@@ -0,0 +1,42 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/notify"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifierPostsDigest(t *testing.T) {
+	var received domain.ReviewDigest
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	notifier := notify.NewWebhookNotifier(receiver.URL, time.Second)
+	require.NoError(t, notifier.SendDigest(context.Background(), testDigest()))
+
+	assert.Equal(t, "u2", received.UserID)
+	require.Len(t, received.PullRequests, 2)
+	assert.Equal(t, "pr-1", received.PullRequests[0].PullRequestID)
+}
+
+func TestWebhookNotifierReportsNon2xx(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	notifier := notify.NewWebhookNotifier(receiver.URL, time.Second)
+	assert.Error(t, notifier.SendDigest(context.Background(), testDigest()))
+}
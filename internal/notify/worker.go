@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type jobConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	SendTimeout  time.Duration
+}
+
+// jobWorker delivers a notifier's jobs from the notification_jobs table: jobs are
+// created by the outbox fan-out, claimed with a lease and retried with exponential
+// backoff, so neither a busy notifier nor a restart loses them.
+type jobWorker struct {
+	channel domain.NotificationChannel
+	repo    domain.NotificationRepository
+	cfg     jobConfig
+	send    func(ctx context.Context, event *domain.Event, recipient string) error
+	logger  *slog.Logger
+}
+
+func newJobWorker(
+	channel domain.NotificationChannel,
+	repo domain.NotificationRepository,
+	cfg jobConfig,
+	send func(ctx context.Context, event *domain.Event, recipient string) error,
+	logger *slog.Logger,
+) *jobWorker {
+	return &jobWorker{
+		channel: channel,
+		repo:    repo,
+		cfg:     cfg,
+		send:    send,
+		logger:  logger,
+	}
+}
+
+func (w *jobWorker) enqueue(ctx context.Context, event *domain.Event, recipients ...string) error {
+	for _, recipient := range recipients {
+		if err := w.repo.CreateJob(ctx, w.channel, event.EventID, recipient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *jobWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.deliverBatch(ctx); err != nil {
+				w.logger.Error(string(w.channel)+" notification delivery failed", "error", err)
+			}
+		}
+	}
+}
+
+func (w *jobWorker) deliverBatch(ctx context.Context) error {
+	tasks, err := w.repo.ClaimDueJobs(ctx, w.channel, w.cfg.BatchSize, w.cfg.PollInterval+w.cfg.SendTimeout+w.cfg.MaxBackoff)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		ctx := domain.WithTenant(ctx, task.Event.TenantID)
+		sendCtx, cancel := context.WithTimeout(ctx, w.cfg.SendTimeout)
+		sendErr := w.send(sendCtx, &task.Event, task.Recipient)
+		cancel()
+		if sendErr == nil {
+			if err := w.repo.MarkJobDelivered(ctx, task.JobID); err != nil {
+				w.logger.Error("failed to mark notification job delivered", "job_id", task.JobID, "error", err)
+			}
+			continue
+		}
+		w.fail(ctx, task, sendErr)
+	}
+	return nil
+}
+
+func (w *jobWorker) fail(ctx context.Context, task *domain.NotificationTask, sendErr error) {
+	attempts := task.Attempts + 1
+	dead := attempts >= w.cfg.MaxAttempts || isNotFound(sendErr)
+	if err := w.repo.MarkJobFailed(ctx, task.JobID, sendErr.Error(), time.Now().Add(w.backoff(attempts)), dead); err != nil {
+		w.logger.Error("failed to mark notification job failed", "job_id", task.JobID, "error", err)
+	}
+
+	attrs := []any{"event_id", task.Event.EventID, "recipient", task.Recipient, "attempts", attempts, "error", sendErr}
+	if dead {
+		w.logger.Error(string(w.channel)+" notification failed", attrs...)
+		return
+	}
+	w.logger.Warn(string(w.channel)+" notification failed, will retry", attrs...)
+}
+
+func (w *jobWorker) backoff(attempts int) time.Duration {
+	delay := w.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < w.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.cfg.MaxBackoff {
+		delay = w.cfg.MaxBackoff
+	}
+	return delay
+}
+
+func isNotFound(err error) bool {
+	var domainErr *domain.DomainError
+	return errors.As(err, &domainErr) && domainErr.Code == domain.ErrorCodeNotFound
+}
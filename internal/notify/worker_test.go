@@ -0,0 +1,107 @@
+package notify_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryJob struct {
+	channel       domain.NotificationChannel
+	eventID       int64
+	recipient     string
+	status        domain.EventStatus
+	attempts      int
+	nextAttemptAt time.Time
+}
+
+type memoryNotificationRepo struct {
+	mu     sync.Mutex
+	events map[int64]domain.Event
+	jobs   []*memoryJob
+}
+
+func newMemoryNotificationRepo() *memoryNotificationRepo {
+	return &memoryNotificationRepo{events: map[int64]domain.Event{}}
+}
+
+// publish stores the event the way the outbox does and runs the notifier's fan-out.
+func (r *memoryNotificationRepo) publish(t *testing.T, enqueue func(ctx context.Context, event *domain.Event) error, event *domain.Event) {
+	r.mu.Lock()
+	event.EventID = int64(len(r.events) + 1)
+	r.events[event.EventID] = *event
+	r.mu.Unlock()
+	require.NoError(t, enqueue(context.Background(), event))
+}
+
+func (r *memoryNotificationRepo) snapshot() []memoryJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := make([]memoryJob, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+func (r *memoryNotificationRepo) CreateJob(ctx context.Context, channel domain.NotificationChannel, eventID int64, recipient string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs = append(r.jobs, &memoryJob{
+		channel:       channel,
+		eventID:       eventID,
+		recipient:     recipient,
+		status:        domain.EventStatusPending,
+		nextAttemptAt: time.Now(),
+	})
+	return nil
+}
+
+func (r *memoryNotificationRepo) ClaimDueJobs(ctx context.Context, channel domain.NotificationChannel, limit int, lease time.Duration) ([]*domain.NotificationTask, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var tasks []*domain.NotificationTask
+	for i, job := range r.jobs {
+		if len(tasks) == limit {
+			break
+		}
+		if job.channel != channel || job.status != domain.EventStatusPending || job.nextAttemptAt.After(now) {
+			continue
+		}
+		job.nextAttemptAt = now.Add(lease)
+		tasks = append(tasks, &domain.NotificationTask{
+			JobID:     int64(i),
+			Attempts:  job.attempts,
+			Recipient: job.recipient,
+			Event:     r.events[job.eventID],
+		})
+	}
+	return tasks, nil
+}
+
+func (r *memoryNotificationRepo) MarkJobDelivered(ctx context.Context, jobID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobs[jobID]
+	job.status = domain.EventStatusDelivered
+	job.attempts++
+	return nil
+}
+
+func (r *memoryNotificationRepo) MarkJobFailed(ctx context.Context, jobID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobs[jobID]
+	job.attempts++
+	job.nextAttemptAt = nextAttemptAt
+	if dead {
+		job.status = domain.EventStatusDead
+	}
+	return nil
+}
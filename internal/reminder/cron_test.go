@@ -0,0 +1,59 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"avitotest/internal/reminder"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// 2024-03-01 is a Friday.
+	base := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", base, base.Add(time.Minute)},
+		{"weekday mornings skip weekend", "0 9 * * 1-5", base, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"same day later", "0 17 * * 1-5", base, time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC)},
+		{"step minutes", "*/15 * * * *", base, time.Date(2024, 3, 1, 10, 45, 0, 0, time.UTC)},
+		{"list of hours", "0 8,12,18 * * *", base, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"sunday as seven", "0 9 * * 7", base, time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC)},
+		{"day of month or weekday", "0 9 15 * 1", base, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"month rollover", "0 0 1 * *", base, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := reminder.ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(tt.from))
+		})
+	}
+}
+
+func TestParseScheduleRejectsInvalidSpecs(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		_, err := reminder.ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,79 @@
+package reminder
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type DigestBuilder interface {
+	BuildDigests(ctx context.Context) ([]*domain.ReviewDigest, error)
+}
+
+type Scheduler struct {
+	userRepo domain.UserRepository
+	builder  DigestBuilder
+	notifier domain.DigestNotifier
+	schedule *Schedule
+	logger   *slog.Logger
+}
+
+func NewScheduler(userRepo domain.UserRepository, builder DigestBuilder, notifier domain.DigestNotifier, schedule *Schedule, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		userRepo: userRepo,
+		builder:  builder,
+		notifier: notifier,
+		schedule: schedule,
+		logger:   logger,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		next := s.schedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.Error("reminder schedule never fires")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if err := s.SendDigests(ctx); err != nil {
+				s.logger.Error("reminder digests failed", "error", err)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) SendDigests(ctx context.Context) error {
+	tenants, err := s.userRepo.ListTenantIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, tenant := range tenants {
+		ctx := domain.WithTenant(ctx, tenant)
+		digests, err := s.builder.BuildDigests(ctx)
+		if err != nil {
+			s.logger.Error("failed to build reminder digests", "tenant_id", tenant, "error", err)
+			continue
+		}
+
+		for _, digest := range digests {
+			if err := s.notifier.SendDigest(ctx, digest); err != nil {
+				s.logger.Error("failed to send reminder digest",
+					"tenant_id", tenant,
+					"user_id", digest.UserID,
+					"error", err,
+				)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package reminder_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/reminder"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantsRepo struct {
+	domain.UserRepository
+	tenants []string
+}
+
+func (r *tenantsRepo) ListTenantIDs(ctx context.Context) ([]string, error) {
+	return r.tenants, nil
+}
+
+type builderFunc func(ctx context.Context) ([]*domain.ReviewDigest, error)
+
+func (f builderFunc) BuildDigests(ctx context.Context) ([]*domain.ReviewDigest, error) {
+	return f(ctx)
+}
+
+type recordingNotifier struct {
+	sent []string
+}
+
+func (n *recordingNotifier) SendDigest(ctx context.Context, digest *domain.ReviewDigest) error {
+	n.sent = append(n.sent, domain.TenantFromContext(ctx)+"/"+digest.UserID)
+	if digest.UserID == "broken" {
+		return errors.New("mailbox unavailable")
+	}
+	return nil
+}
+
+func TestSendDigestsPerTenant(t *testing.T) {
+	builder := builderFunc(func(ctx context.Context) ([]*domain.ReviewDigest, error) {
+		switch domain.TenantFromContext(ctx) {
+		case "acme":
+			return []*domain.ReviewDigest{{UserID: "broken"}, {UserID: "u1"}}, nil
+		case "globex":
+			return nil, errors.New("database unavailable")
+		default:
+			return []*domain.ReviewDigest{{UserID: "u2"}}, nil
+		}
+	})
+	notifier := &recordingNotifier{}
+
+	schedule, err := reminder.ParseSchedule("0 9 * * 1-5")
+	require.NoError(t, err)
+	scheduler := reminder.NewScheduler(
+		&tenantsRepo{tenants: []string{"acme", "globex", "initech"}},
+		builder,
+		notifier,
+		schedule,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	require.NoError(t, scheduler.SendDigests(context.Background()))
+	assert.Equal(t, []string{"acme/broken", "acme/u1", "initech/u2"}, notifier.sent)
+}
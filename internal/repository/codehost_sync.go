@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type codeHostSyncRepository struct {
+	db *sql.DB
+}
+
+func NewCodeHostSyncRepository(db *sql.DB) domain.CodeHostSyncRepository {
+	return &codeHostSyncRepository{db: db}
+}
+
+func (r *codeHostSyncRepository) CreateJob(ctx context.Context, eventID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO codehost_sync_jobs (event_id, tenant_id)
+		VALUES ($1, $2)
+		ON CONFLICT (event_id) DO NOTHING
+	`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to create code host sync job: %w", err)
+	}
+	return nil
+}
+
+func (r *codeHostSyncRepository) ClaimDueJobs(ctx context.Context, limit int, lease time.Duration) ([]*domain.CodeHostSyncTask, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		WITH claimed AS (
+			UPDATE codehost_sync_jobs
+			SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+			WHERE event_id IN (
+				SELECT event_id
+				FROM codehost_sync_jobs
+				WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+				ORDER BY event_id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING event_id, attempts
+		)
+		SELECT c.attempts, e.event_id, e.tenant_id, e.event_type, e.aggregate_id, e.payload, e.occurred_at
+		FROM claimed c
+		JOIN outbox_events e ON e.event_id = c.event_id
+		ORDER BY c.event_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim code host sync jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.CodeHostSyncTask
+	for rows.Next() {
+		var task domain.CodeHostSyncTask
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(
+			&task.Attempts,
+			&task.Event.EventID,
+			&task.Event.TenantID,
+			&eventType,
+			&task.Event.AggregateID,
+			&payload,
+			&task.Event.OccurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan code host sync job: %w", err)
+		}
+		task.Event.EventType = domain.EventType(eventType)
+		task.Event.Payload = payload
+		tasks = append(tasks, &task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate code host sync jobs: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *codeHostSyncRepository) MarkJobSynced(ctx context.Context, eventID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		UPDATE codehost_sync_jobs
+		SET status = 'DELIVERED', attempts = attempts + 1, last_error = NULL, synced_at = NOW()
+		WHERE event_id = $1 AND tenant_id = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark code host sync job synced: %w", err)
+	}
+	return nil
+}
+
+func (r *codeHostSyncRepository) MarkJobFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := domain.EventStatusPending
+	if dead {
+		status = domain.EventStatusDead
+	}
+
+	query := `
+		UPDATE codehost_sync_jobs
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE event_id = $1 AND tenant_id = $5
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, string(status), lastError, nextAttemptAt, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark code host sync job failed: %w", err)
+	}
+	return nil
+}
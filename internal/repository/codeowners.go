@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"avitotest/internal/domain"
+)
+
+type codeOwnersRepository struct {
+	db *sql.DB
+}
+
+func NewCodeOwnersRepository(db *sql.DB) domain.CodeOwnersRepository {
+	return &codeOwnersRepository{db: db}
+}
+
+func (r *codeOwnersRepository) Upsert(ctx context.Context, ruleset *domain.CodeOwnersRuleset) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO codeowners_rulesets (scope, scope_name, content, updated_at, tenant_id)
+		VALUES ($1, $2, $3, NOW(), $4)
+		ON CONFLICT (tenant_id, scope, scope_name)
+		DO UPDATE SET content = $3, updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, string(ruleset.Scope), ruleset.ScopeName, ruleset.Content, tenantID(ctx)).
+		Scan(&ruleset.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save codeowners ruleset: %w", err)
+	}
+	return nil
+}
+
+func (r *codeOwnersRepository) Get(ctx context.Context, scope domain.CodeOwnersScope, scopeName string) (*domain.CodeOwnersRuleset, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT scope, scope_name, content, updated_at
+		FROM codeowners_rulesets
+		WHERE scope = $1 AND scope_name = $2 AND tenant_id = $3
+	`
+
+	var ruleset domain.CodeOwnersRuleset
+	var scopeStr string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, string(scope), scopeName, tenantID(ctx)).Scan(
+		&scopeStr,
+		&ruleset.ScopeName,
+		&ruleset.Content,
+		&ruleset.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "codeowners ruleset not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get codeowners ruleset: %w", err)
+	}
+	ruleset.Scope = domain.CodeOwnersScope(scopeStr)
+	return &ruleset, nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"avitotest/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+type exclusionRepository struct {
+	db *sql.DB
+}
+
+func NewExclusionRepository(db *sql.DB) domain.ExclusionRepository {
+	return &exclusionRepository{db: db}
+}
+
+func (r *exclusionRepository) Upsert(ctx context.Context, exclusion *domain.ReviewerExclusion) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO reviewer_exclusions (user_id, excluded_user_id, symmetric, reason, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, user_id, excluded_user_id)
+		DO UPDATE SET symmetric = EXCLUDED.symmetric, reason = EXCLUDED.reason
+		RETURNING exclusion_id, created_at
+	`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		exclusion.UserID,
+		exclusion.ExcludedUserID,
+		exclusion.Symmetric,
+		exclusion.Reason,
+		tenantID(ctx),
+	).Scan(&exclusion.ExclusionID, &exclusion.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save reviewer exclusion: %w", err)
+	}
+	return nil
+}
+
+func (r *exclusionRepository) Delete(ctx context.Context, exclusionID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM reviewer_exclusions WHERE exclusion_id = $1 AND tenant_id = $2`, exclusionID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete reviewer exclusion: %w", err)
+	}
+	return requireAffected(result, "exclusion not found")
+}
+
+func (r *exclusionRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.ReviewerExclusion, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT exclusion_id, user_id, excluded_user_id, symmetric, reason, created_at
+		FROM reviewer_exclusions
+		WHERE (user_id = $1 OR excluded_user_id = $1) AND tenant_id = $2
+		ORDER BY exclusion_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewer exclusions: %w", err)
+	}
+	defer rows.Close()
+
+	exclusions := []*domain.ReviewerExclusion{}
+	for rows.Next() {
+		var exclusion domain.ReviewerExclusion
+		if err := rows.Scan(
+			&exclusion.ExclusionID,
+			&exclusion.UserID,
+			&exclusion.ExcludedUserID,
+			&exclusion.Symmetric,
+			&exclusion.Reason,
+			&exclusion.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer exclusion: %w", err)
+		}
+		exclusions = append(exclusions, &exclusion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reviewer exclusions: %w", err)
+	}
+
+	return exclusions, nil
+}
+
+func (r *exclusionRepository) GetExcludedReviewers(ctx context.Context, authorIDs []string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT excluded_user_id FROM reviewer_exclusions WHERE user_id = ANY($1) AND tenant_id = $2
+		UNION
+		SELECT user_id FROM reviewer_exclusions WHERE excluded_user_id = ANY($1) AND symmetric AND tenant_id = $2
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(authorIDs), tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan excluded reviewer: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate excluded reviewers: %w", err)
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"avitotest/internal/domain"
+)
+
+type integrationRepository struct {
+	db *sql.DB
+}
+
+func NewIntegrationRepository(db *sql.DB) domain.IntegrationRepository {
+	return &integrationRepository{db: db}
+}
+
+func (r *integrationRepository) LinkIdentity(ctx context.Context, identity *domain.ExternalIdentity) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO external_identities (provider, external_login, user_id, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, provider, external_login)
+		DO UPDATE SET user_id = $3
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, string(identity.Provider), identity.ExternalLogin, identity.UserID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to link external identity: %w", err)
+	}
+	return nil
+}
+
+func (r *integrationRepository) UnlinkIdentity(ctx context.Context, provider domain.CodeHostProvider, externalLogin string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `DELETE FROM external_identities WHERE provider = $1 AND external_login = $2 AND tenant_id = $3`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, string(provider), externalLogin, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to unlink external identity: %w", err)
+	}
+	return requireAffected(result, "external identity not found")
+}
+
+func (r *integrationRepository) GetUserIDByLogin(ctx context.Context, provider domain.CodeHostProvider, externalLogin string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `SELECT user_id FROM external_identities WHERE provider = $1 AND external_login = $2 AND tenant_id = $3`
+
+	var userID string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, string(provider), externalLogin, tenantID(ctx)).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, fmt.Sprintf("no user linked to %s login %s", provider, externalLogin))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get external identity: %w", err)
+	}
+	return userID, nil
+}
+
+func (r *integrationRepository) GetLoginByUserID(ctx context.Context, provider domain.CodeHostProvider, userID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT external_login
+		FROM external_identities
+		WHERE provider = $1 AND user_id = $2 AND tenant_id = $3
+		ORDER BY created_at
+		LIMIT 1
+	`
+
+	var login string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, string(provider), userID, tenantID(ctx)).Scan(&login)
+	if err == sql.ErrNoRows {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, fmt.Sprintf("user %s has no %s login", userID, provider))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get external identity: %w", err)
+	}
+	return login, nil
+}
+
+func (r *integrationRepository) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*domain.ExternalIdentity, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT provider, external_login, user_id
+		FROM external_identities
+		WHERE user_id = $1 AND tenant_id = $2
+		ORDER BY provider, external_login
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list external identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*domain.ExternalIdentity
+	for rows.Next() {
+		var identity domain.ExternalIdentity
+		var provider string
+		if err := rows.Scan(&provider, &identity.ExternalLogin, &identity.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan external identity: %w", err)
+		}
+		identity.Provider = domain.CodeHostProvider(provider)
+		identities = append(identities, &identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate external identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+func (r *integrationRepository) LinkPullRequest(ctx context.Context, link *domain.ExternalPullRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO external_pull_requests (pull_request_id, provider, repository, number, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, link.PullRequestID, string(link.Provider), link.Repository, link.Number, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to link external pull request: %w", err)
+	}
+	return nil
+}
+
+func (r *integrationRepository) GetPullRequestLink(ctx context.Context, provider domain.CodeHostProvider, repository string, number int) (*domain.ExternalPullRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT pull_request_id, provider, repository, number
+		FROM external_pull_requests
+		WHERE provider = $1 AND repository = $2 AND number = $3 AND tenant_id = $4
+	`
+
+	var link domain.ExternalPullRequest
+	var providerStr string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, string(provider), repository, number, tenantID(ctx)).Scan(
+		&link.PullRequestID,
+		&providerStr,
+		&link.Repository,
+		&link.Number,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "external pull request not linked")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external pull request: %w", err)
+	}
+	link.Provider = domain.CodeHostProvider(providerStr)
+	return &link, nil
+}
+
+func (r *integrationRepository) GetPullRequestLinkByID(ctx context.Context, prID string) (*domain.ExternalPullRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT pull_request_id, provider, repository, number
+		FROM external_pull_requests
+		WHERE pull_request_id = $1 AND tenant_id = $2
+	`
+
+	var link domain.ExternalPullRequest
+	var providerStr string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, prID, tenantID(ctx)).Scan(
+		&link.PullRequestID,
+		&providerStr,
+		&link.Repository,
+		&link.Number,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "external pull request not linked")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external pull request: %w", err)
+	}
+	link.Provider = domain.CodeHostProvider(providerStr)
+	return &link, nil
+}
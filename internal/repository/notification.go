@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) domain.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateJob(ctx context.Context, channel domain.NotificationChannel, eventID int64, recipient string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO notification_jobs (event_id, tenant_id, channel, recipient)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, channel, recipient) DO NOTHING
+	`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, tenantID(ctx), string(channel), recipient); err != nil {
+		return fmt.Errorf("failed to create notification job: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) ClaimDueJobs(ctx context.Context, channel domain.NotificationChannel, limit int, lease time.Duration) ([]*domain.NotificationTask, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		WITH claimed AS (
+			UPDATE notification_jobs
+			SET next_attempt_at = NOW() + $3 * INTERVAL '1 millisecond'
+			WHERE job_id IN (
+				SELECT job_id
+				FROM notification_jobs
+				WHERE channel = $1 AND status = 'PENDING' AND next_attempt_at <= NOW()
+				ORDER BY job_id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING job_id, event_id, recipient, attempts
+		)
+		SELECT c.job_id, c.attempts, c.recipient,
+			e.event_id, e.tenant_id, e.event_type, e.aggregate_id, e.payload, e.occurred_at
+		FROM claimed c
+		JOIN outbox_events e ON e.event_id = c.event_id
+		ORDER BY c.job_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, string(channel), limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notification jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.NotificationTask
+	for rows.Next() {
+		var task domain.NotificationTask
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(
+			&task.JobID,
+			&task.Attempts,
+			&task.Recipient,
+			&task.Event.EventID,
+			&task.Event.TenantID,
+			&eventType,
+			&task.Event.AggregateID,
+			&payload,
+			&task.Event.OccurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification job: %w", err)
+		}
+		task.Event.EventType = domain.EventType(eventType)
+		task.Event.Payload = payload
+		tasks = append(tasks, &task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification jobs: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *notificationRepository) MarkJobDelivered(ctx context.Context, jobID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		UPDATE notification_jobs
+		SET status = 'DELIVERED', attempts = attempts + 1, last_error = NULL, delivered_at = NOW()
+		WHERE job_id = $1 AND tenant_id = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, jobID, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark notification job delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) MarkJobFailed(ctx context.Context, jobID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := domain.EventStatusPending
+	if dead {
+		status = domain.EventStatusDead
+	}
+
+	query := `
+		UPDATE notification_jobs
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE job_id = $1 AND tenant_id = $5
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, jobID, string(status), lastError, nextAttemptAt, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark notification job failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"avitotest/internal/domain"
+)
+
+type orgUnitRepository struct {
+	db *sql.DB
+}
+
+func NewOrgUnitRepository(db *sql.DB) domain.OrgUnitRepository {
+	return &orgUnitRepository{db: db}
+}
+
+func (r *orgUnitRepository) Create(ctx context.Context, unit *domain.OrgUnit) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO org_units (unit_id, name, parent_id, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, unit_id) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, unit.UnitID, unit.Name, unit.ParentID, tenantID(ctx)).Scan(&unit.CreatedAt)
+	if err == sql.ErrNoRows {
+		return domain.NewDomainError(domain.ErrorCodeOrgUnitExists, "org unit already exists")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create org unit: %w", err)
+	}
+	return nil
+}
+
+func (r *orgUnitRepository) Get(ctx context.Context, unitID string) (*domain.OrgUnit, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `SELECT unit_id, name, parent_id, created_at FROM org_units WHERE unit_id = $1 AND tenant_id = $2`
+
+	var unit domain.OrgUnit
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, unitID, tenantID(ctx)).Scan(&unit.UnitID, &unit.Name, &unit.ParentID, &unit.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "org unit not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org unit: %w", err)
+	}
+
+	unit.Children, err = r.queryStrings(ctx, `SELECT unit_id FROM org_units WHERE parent_id = $1 AND tenant_id = $2 ORDER BY unit_id`, unitID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org unit children: %w", err)
+	}
+	unit.Teams, err = r.queryStrings(ctx, `SELECT team_name FROM org_unit_teams WHERE unit_id = $1 AND tenant_id = $2 ORDER BY team_name`, unitID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org unit teams: %w", err)
+	}
+	return &unit, nil
+}
+
+func (r *orgUnitRepository) List(ctx context.Context) ([]*domain.OrgUnit, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT unit_id, name, parent_id, created_at FROM org_units WHERE tenant_id = $1 ORDER BY unit_id`, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org units: %w", err)
+	}
+	defer rows.Close()
+
+	units := []*domain.OrgUnit{}
+	byID := map[string]*domain.OrgUnit{}
+	for rows.Next() {
+		unit := &domain.OrgUnit{Children: []string{}, Teams: []string{}}
+		if err := rows.Scan(&unit.UnitID, &unit.Name, &unit.ParentID, &unit.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan org unit: %w", err)
+		}
+		units = append(units, unit)
+		byID[unit.UnitID] = unit
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate org units: %w", err)
+	}
+
+	for _, unit := range units {
+		if unit.ParentID != nil {
+			if parent, ok := byID[*unit.ParentID]; ok {
+				parent.Children = append(parent.Children, unit.UnitID)
+			}
+		}
+	}
+
+	teamRows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT team_name, unit_id FROM org_unit_teams WHERE tenant_id = $1 ORDER BY team_name`, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org unit teams: %w", err)
+	}
+	defer teamRows.Close()
+
+	for teamRows.Next() {
+		var teamName, unitID string
+		if err := teamRows.Scan(&teamName, &unitID); err != nil {
+			return nil, fmt.Errorf("failed to scan org unit team: %w", err)
+		}
+		if unit, ok := byID[unitID]; ok {
+			unit.Teams = append(unit.Teams, teamName)
+		}
+	}
+	if err := teamRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate org unit teams: %w", err)
+	}
+
+	return units, nil
+}
+
+func (r *orgUnitRepository) Update(ctx context.Context, unit *domain.OrgUnit) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE org_units SET name = $2, parent_id = $3 WHERE unit_id = $1 AND tenant_id = $4`,
+		unit.UnitID, unit.Name, unit.ParentID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update org unit: %w", err)
+	}
+	return requireAffected(result, "org unit not found")
+}
+
+func (r *orgUnitRepository) Delete(ctx context.Context, unitID string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM org_units WHERE unit_id = $1 AND tenant_id = $2`, unitID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete org unit: %w", err)
+	}
+	return requireAffected(result, "org unit not found")
+}
+
+func (r *orgUnitRepository) GetAncestorIDs(ctx context.Context, unitID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT unit_id, parent_id, 0 AS depth FROM org_units WHERE unit_id = $1 AND tenant_id = $2
+			UNION ALL
+			SELECT u.unit_id, u.parent_id, a.depth + 1
+			FROM org_units u
+			JOIN ancestors a ON u.unit_id = a.parent_id
+			WHERE u.tenant_id = $2
+		)
+		SELECT unit_id FROM ancestors WHERE depth > 0 ORDER BY depth
+	`
+
+	unitIDs, err := r.queryStrings(ctx, query, unitID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org unit ancestors: %w", err)
+	}
+	return unitIDs, nil
+}
+
+func (r *orgUnitRepository) SetTeamUnit(ctx context.Context, teamName string, unitID *string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO org_unit_teams (tenant_id, team_name, unit_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, team_name) DO UPDATE SET unit_id = EXCLUDED.unit_id
+	`
+	args := []interface{}{tenantID(ctx), teamName, unitID}
+	if unitID == nil {
+		query = `DELETE FROM org_unit_teams WHERE tenant_id = $1 AND team_name = $2`
+		args = args[:2]
+	}
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to set team org unit: %w", err)
+	}
+	return nil
+}
+
+func (r *orgUnitRepository) GetUnitIDByTeam(ctx context.Context, teamName string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var unitID string
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT unit_id FROM org_unit_teams WHERE team_name = $1 AND tenant_id = $2`, teamName, tenantID(ctx)).Scan(&unitID)
+	if err == sql.ErrNoRows {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, "team is not assigned to an org unit")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get team org unit: %w", err)
+	}
+	return unitID, nil
+}
+
+func (r *orgUnitRepository) GetSubtreeTeams(ctx context.Context, unitID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT unit_id FROM org_units WHERE unit_id = $1 AND tenant_id = $2
+			UNION ALL
+			SELECT u.unit_id
+			FROM org_units u
+			JOIN subtree s ON u.parent_id = s.unit_id
+			WHERE u.tenant_id = $2
+		)
+		SELECT t.team_name
+		FROM org_unit_teams t
+		JOIN subtree s ON s.unit_id = t.unit_id
+		WHERE t.tenant_id = $2
+		ORDER BY t.team_name
+	`
+
+	teamNames, err := r.queryStrings(ctx, query, unitID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org unit subtree teams: %w", err)
+	}
+	return teamNames, nil
+}
+
+func (r *orgUnitRepository) queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avitotest/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+type outboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) domain.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Append(ctx context.Context, events ...*domain.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO outbox_events (event_type, aggregate_id, payload, occurred_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING event_id
+	`
+
+	for _, event := range events {
+		event.TenantID = tenantID(ctx)
+		err := conn(ctx, r.db).QueryRowContext(ctx, query,
+			string(event.EventType),
+			event.AggregateID,
+			[]byte(event.Payload),
+			event.OccurredAt,
+			event.TenantID,
+		).Scan(&event.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to append outbox event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, eventID int64) (*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT event_id, tenant_id, event_type, aggregate_id, payload, occurred_at
+		FROM outbox_events
+		WHERE event_id = $1 AND tenant_id = $2
+	`
+
+	var event domain.Event
+	var eventType string
+	var payload []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, eventID, tenantID(ctx)).Scan(
+		&event.EventID,
+		&event.TenantID,
+		&eventType,
+		&event.AggregateID,
+		&payload,
+		&event.OccurredAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "event not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox event: %w", err)
+	}
+
+	event.EventType = domain.EventType(eventType)
+	event.Payload = payload
+	return &event, nil
+}
+
+func (r *outboxRepository) ListAfter(ctx context.Context, afterID int64, eventTypes []domain.EventType, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	types := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		types = append(types, string(eventType))
+	}
+
+	query := `
+		SELECT event_id, tenant_id, event_type, aggregate_id, payload, occurred_at
+		FROM outbox_events
+		WHERE tenant_id = $1 AND event_id > $2 AND event_type = ANY($3)
+		ORDER BY event_id
+		LIMIT $4
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, tenantID(ctx), afterID, pq.Array(types), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.Event{}
+	for rows.Next() {
+		var event domain.Event
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(
+			&event.EventID,
+			&event.TenantID,
+			&eventType,
+			&event.AggregateID,
+			&payload,
+			&event.OccurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		event.EventType = domain.EventType(eventType)
+		event.Payload = payload
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *outboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		UPDATE outbox_events
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE event_id IN (
+			SELECT event_id
+			FROM outbox_events
+			WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+			ORDER BY event_id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING event_id, tenant_id, event_type, aggregate_id, payload, occurred_at, status, attempts, COALESCE(last_error, '')
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.OutboxEntry
+	for rows.Next() {
+		var entry domain.OutboxEntry
+		var eventType, status string
+		var payload []byte
+		if err := rows.Scan(
+			&entry.EventID,
+			&entry.TenantID,
+			&eventType,
+			&entry.AggregateID,
+			&payload,
+			&entry.OccurredAt,
+			&status,
+			&entry.Attempts,
+			&entry.LastError,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		entry.EventType = domain.EventType(eventType)
+		entry.Status = domain.EventStatus(status)
+		entry.Payload = payload
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *outboxRepository) MarkDelivered(ctx context.Context, eventID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		UPDATE outbox_events
+		SET status = 'DELIVERED', attempts = attempts + 1, last_error = NULL, delivered_at = NOW()
+		WHERE event_id = $1 AND tenant_id = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := domain.EventStatusPending
+	if dead {
+		status = domain.EventStatusDead
+	}
+
+	query := `
+		UPDATE outbox_events
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE event_id = $1 AND tenant_id = $5
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, eventID, string(status), lastError, nextAttemptAt, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
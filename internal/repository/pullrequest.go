@@ -10,6 +10,9 @@ import (
 	"avitotest/internal/domain"
 )
 
+const pullRequestColumns = `pull_request_id, pull_request_name, COALESCE(author_id, ''), co_author_ids, review_team, status,
+		       assigned_reviewers, labels, assignment, created_at, merged_at, first_verdict_at`
+
 type pullRequestRepository struct {
 	db *sql.DB
 }
@@ -26,20 +29,37 @@ func (r *pullRequestRepository) Create(ctx context.Context, pr *domain.PullReque
 	if err != nil {
 		return fmt.Errorf("failed to marshal reviewers: %w", err)
 	}
+	labelsJSON, err := json.Marshal(nonNil(pr.Labels))
+	if err != nil {
+		return fmt.Errorf("failed to marshal labels: %w", err)
+	}
+	coAuthorsJSON, err := json.Marshal(nonNil(pr.CoAuthorIDs))
+	if err != nil {
+		return fmt.Errorf("failed to marshal co-authors: %w", err)
+	}
+	assignmentJSON, err := marshalAssignment(pr.Assignment)
+	if err != nil {
+		return err
+	}
 
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, co_author_ids, review_team, status, assigned_reviewers, labels, assignment, created_at, tenant_id)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	now := time.Now()
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
+		coAuthorsJSON,
+		pr.ReviewTeam,
 		string(pr.Status),
 		reviewersJSON,
+		labelsJSON,
+		assignmentJSON,
 		now,
+		tenantID(ctx),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create pull request: %w", err)
@@ -54,26 +74,12 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, prID string) (*doma
 	defer cancel()
 
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, 
-		       assigned_reviewers, created_at, merged_at
+		SELECT ` + pullRequestColumns + `
 		FROM pull_requests
-		WHERE pull_request_id = $1
+		WHERE pull_request_id = $1 AND tenant_id = $2
 	`
 
-	var pr domain.PullRequest
-	var statusStr string
-	var reviewersJSON []byte
-	var createdAt, mergedAt sql.NullTime
-
-	err := r.db.QueryRowContext(ctx, query, prID).Scan(
-		&pr.PullRequestID,
-		&pr.PullRequestName,
-		&pr.AuthorID,
-		&statusStr,
-		&reviewersJSON,
-		&createdAt,
-		&mergedAt,
-	)
+	pr, err := scanPullRequest(conn(ctx, r.db).QueryRowContext(ctx, query, prID, tenantID(ctx)))
 	if err == sql.ErrNoRows {
 		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pull request not found")
 	}
@@ -81,19 +87,7 @@ func (r *pullRequestRepository) GetByID(ctx context.Context, prID string) (*doma
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
 	}
 
-	pr.Status = domain.PRStatus(statusStr)
-	if err := json.Unmarshal(reviewersJSON, &pr.AssignedReviewers); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal reviewers: %w", err)
-	}
-
-	if createdAt.Valid {
-		pr.CreatedAt = &createdAt.Time
-	}
-	if mergedAt.Valid {
-		pr.MergedAt = &mergedAt.Time
-	}
-
-	return &pr, nil
+	return pr, nil
 }
 
 func (r *pullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID string) ([]*domain.PullRequest, error) {
@@ -101,13 +95,12 @@ func (r *pullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID
 	defer cancel()
 
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, 
-		       assigned_reviewers, created_at, merged_at
+		SELECT ` + pullRequestColumns + `
 		FROM pull_requests
-		WHERE assigned_reviewers::jsonb ? $1
+		WHERE assigned_reviewers::jsonb ? $1 AND tenant_id = $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, reviewerID)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, reviewerID, tenantID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pull requests by reviewer: %w", err)
 	}
@@ -115,29 +108,11 @@ func (r *pullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID
 
 	var prs []*domain.PullRequest
 	for rows.Next() {
-		var pr domain.PullRequest
-		var statusStr string
-		var reviewersJSON []byte
-		var createdAt, mergedAt sql.NullTime
-
-		if err := rows.Scan(
-			&pr.PullRequestID,
-			&pr.PullRequestName,
-			&pr.AuthorID,
-			&statusStr,
-			&reviewersJSON,
-			&createdAt,
-			&mergedAt,
-		); err != nil {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan pull request: %w", err)
 		}
-
-		pr.Status = domain.PRStatus(statusStr)
-		if err := json.Unmarshal(reviewersJSON, &pr.AssignedReviewers); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal reviewers: %w", err)
-		}
-
-		prs = append(prs, &pr)
+		prs = append(prs, pr)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -155,21 +130,29 @@ func (r *pullRequestRepository) Update(ctx context.Context, pr *domain.PullReque
 	if err != nil {
 		return fmt.Errorf("failed to marshal reviewers: %w", err)
 	}
+	assignmentJSON, err := marshalAssignment(pr.Assignment)
+	if err != nil {
+		return err
+	}
 
 	query := `
 		UPDATE pull_requests
-		SET pull_request_name = $2, author_id = $3, status = $4, 
-		    assigned_reviewers = $5, merged_at = $6
-		WHERE pull_request_id = $1
+		SET pull_request_name = $2, author_id = NULLIF($3, ''), status = $4, 
+		    assigned_reviewers = $5, merged_at = $6, assignment = COALESCE($7, assignment),
+		    first_verdict_at = $9
+		WHERE pull_request_id = $1 AND tenant_id = $8
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
 		pr.PullRequestID,
 		pr.PullRequestName,
 		pr.AuthorID,
 		string(pr.Status),
 		reviewersJSON,
 		pr.MergedAt,
+		assignmentJSON,
+		tenantID(ctx),
+		pr.FirstVerdictAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update pull request: %w", err)
@@ -182,12 +165,82 @@ func (r *pullRequestRepository) Exists(ctx context.Context, prID string) (bool,
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	query := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1 LIMIT 1)`
+	query := `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1 AND tenant_id = $2 LIMIT 1)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, prID).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, prID, tenantID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check pull request existence: %w", err)
 	}
 	return exists, nil
 }
+
+func scanPullRequest(row rowScanner) (*domain.PullRequest, error) {
+	var pr domain.PullRequest
+	var statusStr string
+	var coAuthorsJSON, reviewersJSON, labelsJSON, assignmentJSON []byte
+	var createdAt, mergedAt, firstVerdictAt sql.NullTime
+
+	if err := row.Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&coAuthorsJSON,
+		&pr.ReviewTeam,
+		&statusStr,
+		&reviewersJSON,
+		&labelsJSON,
+		&assignmentJSON,
+		&createdAt,
+		&mergedAt,
+		&firstVerdictAt,
+	); err != nil {
+		return nil, err
+	}
+
+	pr.Status = domain.PRStatus(statusStr)
+	if err := json.Unmarshal(coAuthorsJSON, &pr.CoAuthorIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal co-authors: %w", err)
+	}
+	if err := json.Unmarshal(reviewersJSON, &pr.AssignedReviewers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reviewers: %w", err)
+	}
+	if err := json.Unmarshal(labelsJSON, &pr.Labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal labels: %w", err)
+	}
+	if assignmentJSON != nil {
+		if err := json.Unmarshal(assignmentJSON, &pr.Assignment); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal assignment: %w", err)
+		}
+	}
+
+	if createdAt.Valid {
+		pr.CreatedAt = &createdAt.Time
+	}
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+	if firstVerdictAt.Valid {
+		pr.FirstVerdictAt = &firstVerdictAt.Time
+	}
+
+	return &pr, nil
+}
+
+func marshalAssignment(assignment *domain.AssignmentExplanation) ([]byte, error) {
+	if assignment == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(assignment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assignment: %w", err)
+	}
+	return data, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
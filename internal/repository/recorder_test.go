@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordedQuery struct {
+	query string
+	args  []driver.NamedValue
+}
+
+type queryRecorder struct {
+	mu      sync.Mutex
+	queries []recordedQuery
+}
+
+func newRecordingDB(t *testing.T) (*sql.DB, *queryRecorder) {
+	t.Helper()
+	rec := &queryRecorder{}
+	db := sql.OpenDB(rec)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, rec
+}
+
+func (r *queryRecorder) record(query string, args []driver.NamedValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries = append(r.queries, recordedQuery{query: query, args: args})
+}
+
+func (r *queryRecorder) take() []recordedQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queries := r.queries
+	r.queries = nil
+	return queries
+}
+
+func (r *queryRecorder) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{rec: r}, nil
+}
+
+func (r *queryRecorder) Driver() driver.Driver {
+	return recordingDriver{r}
+}
+
+type recordingDriver struct{ rec *queryRecorder }
+
+func (d recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{rec: d.rec}, nil
+}
+
+type recordingConn struct{ rec *queryRecorder }
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return recordingStmt{conn: c, query: query}, nil
+}
+
+func (c *recordingConn) Close() error { return nil }
+
+func (c *recordingConn) Begin() (driver.Tx, error) { return recordingTx{}, nil }
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.rec.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.rec.record(query, args)
+	return emptyRows{}, nil
+}
+
+type recordingStmt struct {
+	conn  *recordingConn
+	query string
+}
+
+func (s recordingStmt) Close() error  { return nil }
+func (s recordingStmt) NumInput() int { return -1 }
+
+func (s recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, namedValues(args))
+}
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error   { return nil }
+func (recordingTx) Rollback() error { return nil }
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+func normalizeQuery(query string) string {
+	return strings.TrimSpace(whitespace.ReplaceAllString(query, " "))
+}
+
+func findQuery(queries []recordedQuery, prefix string) (recordedQuery, bool) {
+	for _, q := range queries {
+		if strings.HasPrefix(normalizeQuery(q.query), prefix) {
+			return q, true
+		}
+	}
+	return recordedQuery{}, false
+}
+
+func argValues(args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type slaRepository struct {
+	db *sql.DB
+}
+
+func NewSLARepository(db *sql.DB) domain.SLARepository {
+	return &slaRepository{db: db}
+}
+
+func (r *slaRepository) FindBreaches(ctx context.Context, now time.Time, limit int) ([]*domain.SLABreach, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT pr.tenant_id, pr.pull_request_id, t.team_name, k.kind, k.sla_seconds
+		FROM pull_requests pr
+		LEFT JOIN users a ON a.tenant_id = pr.tenant_id AND a.user_id = pr.author_id
+		JOIN team_policies t ON t.tenant_id = pr.tenant_id
+			AND t.team_name = COALESCE(NULLIF(pr.review_team, ''), a.team_name)
+		CROSS JOIN LATERAL (VALUES
+			('first_verdict', t.first_verdict_sla_seconds, pr.first_verdict_at IS NULL),
+			('merge', t.merge_sla_seconds, TRUE)
+		) AS k(kind, sla_seconds, pending)
+		WHERE pr.status = 'OPEN' AND k.pending AND k.sla_seconds > 0
+			AND pr.created_at + k.sla_seconds * INTERVAL '1 second' <= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM sla_breaches b
+				WHERE b.tenant_id = pr.tenant_id AND b.pull_request_id = pr.pull_request_id AND b.kind = k.kind
+			)
+		ORDER BY pr.created_at
+		LIMIT $2
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sla breaches: %w", err)
+	}
+	defer rows.Close()
+
+	var breaches []*domain.SLABreach
+	for rows.Next() {
+		var breach domain.SLABreach
+		var kind string
+		if err := rows.Scan(&breach.TenantID, &breach.PullRequestID, &breach.TeamName, &kind, &breach.SLASeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan sla breach: %w", err)
+		}
+		breach.Kind = domain.SLAKind(kind)
+		breaches = append(breaches, &breach)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sla breaches: %w", err)
+	}
+
+	return breaches, nil
+}
+
+func (r *slaRepository) RecordBreach(ctx context.Context, breach *domain.SLABreach) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO sla_breaches (tenant_id, pull_request_id, team_name, kind, sla_seconds, breached_at, backup_reviewer_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+		ON CONFLICT (tenant_id, pull_request_id, kind) DO NOTHING
+		RETURNING breach_id
+	`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID(ctx),
+		breach.PullRequestID,
+		breach.TeamName,
+		string(breach.Kind),
+		breach.SLASeconds,
+		breach.BreachedAt,
+		breach.BackupReviewerID,
+	).Scan(&breach.BreachID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record sla breach: %w", err)
+	}
+	return true, nil
+}
+
+func (r *slaRepository) ListByPullRequest(ctx context.Context, prID string) ([]*domain.SLABreach, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT breach_id, pull_request_id, team_name, kind, sla_seconds, breached_at, COALESCE(backup_reviewer_id, '')
+		FROM sla_breaches
+		WHERE pull_request_id = $1 AND tenant_id = $2
+		ORDER BY breach_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, prID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sla breaches: %w", err)
+	}
+	defer rows.Close()
+
+	breaches := []*domain.SLABreach{}
+	for rows.Next() {
+		var breach domain.SLABreach
+		var kind string
+		if err := rows.Scan(
+			&breach.BreachID,
+			&breach.PullRequestID,
+			&breach.TeamName,
+			&kind,
+			&breach.SLASeconds,
+			&breach.BreachedAt,
+			&breach.BackupReviewerID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan sla breach: %w", err)
+		}
+		breach.Kind = domain.SLAKind(kind)
+		breach.TenantID = tenantID(ctx)
+		breaches = append(breaches, &breach)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sla breaches: %w", err)
+	}
+
+	return breaches, nil
+}
+
+func (r *slaRepository) RecordVerdict(ctx context.Context, verdict *domain.SubmittedVerdict) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO review_verdicts (tenant_id, pull_request_id, reviewer_id, verdict, submitted_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		tenantID(ctx),
+		verdict.PullRequestID,
+		verdict.ReviewerID,
+		string(verdict.Verdict),
+		verdict.SubmittedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record verdict: %w", err)
+	}
+	return nil
+}
+
+func (r *slaRepository) ListVerdicts(ctx context.Context, prID string) ([]*domain.SubmittedVerdict, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT verdict_id, pull_request_id, reviewer_id, verdict, submitted_at
+		FROM review_verdicts
+		WHERE pull_request_id = $1 AND tenant_id = $2
+		ORDER BY verdict_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, prID, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verdicts: %w", err)
+	}
+	defer rows.Close()
+
+	verdicts := []*domain.SubmittedVerdict{}
+	for rows.Next() {
+		var verdict domain.SubmittedVerdict
+		var value string
+		if err := rows.Scan(
+			&verdict.VerdictID,
+			&verdict.PullRequestID,
+			&verdict.ReviewerID,
+			&value,
+			&verdict.SubmittedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan verdict: %w", err)
+		}
+		verdict.Verdict = domain.ReviewVerdict(value)
+		verdicts = append(verdicts, &verdict)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate verdicts: %w", err)
+	}
+
+	return verdicts, nil
+}
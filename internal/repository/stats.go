@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"avitotest/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+type statsRepository struct {
+	db *sql.DB
+}
+
+func NewStatsRepository(db *sql.DB) domain.StatsRepository {
+	return &statsRepository{db: db}
+}
+
+func (r *statsRepository) GetTeamStats(ctx context.Context, teamNames []string) ([]*domain.TeamStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT t.team_name,
+			(SELECT COUNT(*) FROM team_memberships m
+				JOIN users u ON u.tenant_id = m.tenant_id AND u.user_id = m.user_id
+				WHERE m.tenant_id = $2 AND m.team_name = t.team_name AND u.archived_at IS NULL),
+			(SELECT COUNT(*) FROM team_memberships m
+				JOIN users u ON u.tenant_id = m.tenant_id AND u.user_id = m.user_id
+				WHERE m.tenant_id = $2 AND m.team_name = t.team_name AND u.archived_at IS NULL AND u.is_active),
+			COUNT(p.pull_request_id) FILTER (WHERE p.status = 'OPEN'),
+			COUNT(p.pull_request_id) FILTER (WHERE p.status = 'MERGED'),
+			COUNT(p.pull_request_id) FILTER (WHERE p.status = 'CLOSED'),
+			(SELECT COUNT(*)
+				FROM pull_requests rp
+				JOIN users ru ON ru.tenant_id = rp.tenant_id AND rp.assigned_reviewers ? ru.user_id
+				WHERE rp.tenant_id = $2 AND rp.status = 'OPEN' AND ru.team_name = t.team_name),
+			(SELECT COUNT(*)
+				FROM sla_breaches b
+				JOIN pull_requests bp ON bp.tenant_id = b.tenant_id AND bp.pull_request_id = b.pull_request_id
+				WHERE b.tenant_id = $2 AND COALESCE(NULLIF(bp.review_team, ''), b.team_name) = t.team_name)
+		FROM unnest($1::text[]) AS t(team_name)
+		LEFT JOIN users a ON a.tenant_id = $2 AND a.team_name = t.team_name
+		LEFT JOIN pull_requests p ON p.tenant_id = a.tenant_id AND p.author_id = a.user_id
+		GROUP BY t.team_name
+		ORDER BY t.team_name
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(teamNames), tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*domain.TeamStats{}
+	for rows.Next() {
+		var teamStats domain.TeamStats
+		if err := rows.Scan(
+			&teamStats.TeamName,
+			&teamStats.Members,
+			&teamStats.ActiveMembers,
+			&teamStats.OpenPRs,
+			&teamStats.MergedPRs,
+			&teamStats.ClosedPRs,
+			&teamStats.OpenReviews,
+			&teamStats.SLABreaches,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan team stats: %w", err)
+		}
+		stats = append(stats, &teamStats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate team stats: %w", err)
+	}
+
+	return stats, nil
+}
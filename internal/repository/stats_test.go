@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamStatsCountBreachesByReviewTeam(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	stats := NewStatsRepository(db)
+
+	_, err := stats.GetTeamStats(domain.WithTenant(context.Background(), "t1"), []string{"backend"})
+	require.NoError(t, err)
+
+	q, ok := findQuery(rec.take(), "SELECT t.team_name")
+	require.True(t, ok)
+	assert.Contains(t, normalizeQuery(q.query),
+		"FROM sla_breaches b JOIN pull_requests bp ON bp.tenant_id = b.tenant_id AND bp.pull_request_id = b.pull_request_id "+
+			"WHERE b.tenant_id = $2 AND COALESCE(NULLIF(bp.review_team, ''), b.team_name) = t.team_name")
+}
@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"avitotest/internal/domain"
+
+	"github.com/lib/pq"
 )
 
 type teamRepository struct {
@@ -21,11 +24,12 @@ func (r *teamRepository) Exists(ctx context.Context, teamName string) (bool, err
 	defer cancel()
 	query := `SELECT EXiSTS(
 		SELECT 1
-		FROM USERS
-		WHERE team_name = $1
+		FROM team_memberships m
+		JOIN users u ON u.tenant_id = m.tenant_id AND u.user_id = m.user_id
+		WHERE m.team_name = $1 AND m.tenant_id = $2 AND u.archived_at IS NULL
 		) AS team_exists`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&exists)
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName, tenantID(ctx)).Scan(&exists)
 	if err != nil {
 		return true, fmt.Errorf("failed to get team: %w", err)
 	}
@@ -40,7 +44,7 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
 		return nil
 	}
 	baseQuery := `
-		INSERT INTO users (user_id, username, team_name, is_active) 
+		INSERT INTO users (user_id, username, team_name, is_active, seniority, role, tenant_id, email) 
 		VALUES `
 
 	valuePlaceholders := []string{}
@@ -49,22 +53,50 @@ func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
 
 	for _, member := range team.Members {
 		valuePlaceholders = append(valuePlaceholders,
-			fmt.Sprintf("($%d,$%d,$%d,$%d)",
-				paramCounter, paramCounter+1, paramCounter+2, paramCounter+3))
+			fmt.Sprintf("($%d,$%d,$%d,$%d,COALESCE(NULLIF($%d, ''), 'middle'),COALESCE(NULLIF($%d, ''), 'member'),$%d,$%d)",
+				paramCounter, paramCounter+1, paramCounter+2, paramCounter+3, paramCounter+4, paramCounter+5, paramCounter+6, paramCounter+7))
 
-		params = append(params, member.UserID, member.Username, team.TeamName, member.IsActive)
-		paramCounter += 4
+		params = append(params, member.UserID, member.Username, team.TeamName, member.IsActive, string(member.Seniority), string(member.Role), tenantID(ctx), member.Email)
+		paramCounter += 8
 	}
 
 	finalQuery := baseQuery + strings.Join(valuePlaceholders, ",") + `
-		ON CONFLICT(user_id) DO UPDATE SET 
-			team_name = EXCLUDED.team_name`
+		ON CONFLICT(tenant_id, user_id) DO UPDATE SET 
+			team_name = CASE WHEN users.team_name = '' THEN EXCLUDED.team_name ELSE users.team_name END,
+			email = COALESCE(NULLIF(EXCLUDED.email, ''), users.email)`
 
-	_, err := r.db.ExecContext(ctx, finalQuery, params...)
+	_, err := conn(ctx, r.db).ExecContext(ctx, finalQuery, params...)
 	if err != nil {
 		return fmt.Errorf("failed to update team name for users: %w", err)
 	}
 
+	userIDs := make([]string, 0, len(team.Members))
+	var roleUserIDs, roles []string
+	for _, member := range team.Members {
+		userIDs = append(userIDs, member.UserID)
+		if member.Role != "" {
+			roleUserIDs = append(roleUserIDs, member.UserID)
+			roles = append(roles, string(member.Role))
+		}
+	}
+
+	if len(roles) > 0 {
+		roleQuery := `
+			UPDATE users u SET role = r.role
+			FROM unnest($1::text[], $2::text[]) AS r(user_id, role)
+			WHERE u.user_id = r.user_id AND u.tenant_id = $3 AND u.team_name = $4`
+		if _, err := conn(ctx, r.db).ExecContext(ctx, roleQuery, pq.Array(roleUserIDs), pq.Array(roles), tenantID(ctx), team.TeamName); err != nil {
+			return fmt.Errorf("failed to set team member roles: %w", err)
+		}
+	}
+	membershipQuery := `
+		INSERT INTO team_memberships (user_id, team_name, tenant_id)
+		SELECT unnest($1::text[]), $2, $3
+		ON CONFLICT DO NOTHING`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, membershipQuery, pq.Array(userIDs), team.TeamName, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to add team memberships: %w", err)
+	}
+
 	return nil
 }
 
@@ -72,9 +104,16 @@ func (r *teamRepository) GetByName(ctx context.Context, teamName string) (*domai
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	query := `SELECT user_id, username, is_active FROM users WHERE team_name = $1`
+	query := `
+		SELECT u.user_id, u.username, u.email, u.is_active, u.seniority,
+			CASE WHEN u.team_name = m.team_name THEN u.role ELSE 'member' END,
+			u.team_name = m.team_name
+		FROM team_memberships m
+		JOIN users u ON u.tenant_id = m.tenant_id AND u.user_id = m.user_id
+		WHERE m.team_name = $1 AND m.tenant_id = $2 AND u.archived_at IS NULL
+		ORDER BY u.user_id`
 
-	rows, err := r.db.QueryContext(ctx, query, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName, tenantID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
@@ -83,7 +122,7 @@ func (r *teamRepository) GetByName(ctx context.Context, teamName string) (*domai
 	var members []domain.TeamMember
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.Email, &member.IsActive, &member.Seniority, &member.Role, &member.IsPrimary); err != nil {
 			return nil, fmt.Errorf("failed to scan team member: %w", err)
 		}
 		members = append(members, member)
@@ -102,3 +141,136 @@ func (r *teamRepository) GetByName(ctx context.Context, teamName string) (*domai
 		Members:  members,
 	}, nil
 }
+
+func (r *teamRepository) GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT min_senior_reviewers, lead_review_labels, first_verdict_sla_seconds, merge_sla_seconds
+		FROM team_policies
+		WHERE team_name = $1 AND tenant_id = $2`
+
+	policy := &domain.TeamPolicy{TeamName: teamName, LeadReviewLabels: []string{}}
+	var labelsJSON []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName, tenantID(ctx)).Scan(&policy.MinSeniorReviewers, &labelsJSON, &policy.FirstVerdictSLASeconds, &policy.MergeSLASeconds)
+	if err == sql.ErrNoRows {
+		return policy, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+	if err := json.Unmarshal(labelsJSON, &policy.LeadReviewLabels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal lead review labels: %w", err)
+	}
+	return policy, nil
+}
+
+func (r *teamRepository) SetPolicy(ctx context.Context, policy *domain.TeamPolicy) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	labelsJSON, err := json.Marshal(nonNil(policy.LeadReviewLabels))
+	if err != nil {
+		return fmt.Errorf("failed to marshal lead review labels: %w", err)
+	}
+
+	query := `
+		INSERT INTO team_policies (team_name, min_senior_reviewers, lead_review_labels, tenant_id, first_verdict_sla_seconds, merge_sla_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, team_name)
+		DO UPDATE SET min_senior_reviewers = EXCLUDED.min_senior_reviewers,
+			lead_review_labels = EXCLUDED.lead_review_labels,
+			first_verdict_sla_seconds = EXCLUDED.first_verdict_sla_seconds,
+			merge_sla_seconds = EXCLUDED.merge_sla_seconds
+	`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, policy.TeamName, policy.MinSeniorReviewers, labelsJSON, tenantID(ctx), policy.FirstVerdictSLASeconds, policy.MergeSLASeconds); err != nil {
+		return fmt.Errorf("failed to set team policy: %w", err)
+	}
+	return nil
+}
+
+func (r *teamRepository) GetChatChannel(ctx context.Context, teamName string) (*domain.TeamChatChannel, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT webhook_url, channel
+		FROM team_chat_channels
+		WHERE team_name = $1 AND tenant_id = $2`
+
+	channel := &domain.TeamChatChannel{TeamName: teamName}
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, teamName, tenantID(ctx)).Scan(&channel.WebhookURL, &channel.Channel)
+	if err == sql.ErrNoRows {
+		return channel, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team chat channel: %w", err)
+	}
+	return channel, nil
+}
+
+func (r *teamRepository) SetChatChannel(ctx context.Context, channel *domain.TeamChatChannel) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if channel.WebhookURL == "" {
+		query := `DELETE FROM team_chat_channels WHERE team_name = $1 AND tenant_id = $2`
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, channel.TeamName, tenantID(ctx)); err != nil {
+			return fmt.Errorf("failed to delete team chat channel: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO team_chat_channels (tenant_id, team_name, webhook_url, channel)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, team_name)
+		DO UPDATE SET webhook_url = EXCLUDED.webhook_url, channel = EXCLUDED.channel
+	`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, tenantID(ctx), channel.TeamName, channel.WebhookURL, channel.Channel); err != nil {
+		return fmt.Errorf("failed to set team chat channel: %w", err)
+	}
+	return nil
+}
+
+func (r *teamRepository) Rename(ctx context.Context, teamName, newTeamName string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	queries := []string{
+		`UPDATE users SET team_name = $2 WHERE team_name = $1 AND tenant_id = $3`,
+		`UPDATE team_memberships SET team_name = $2 WHERE team_name = $1 AND tenant_id = $3`,
+		`UPDATE team_policies SET team_name = $2 WHERE team_name = $1 AND tenant_id = $3`,
+		`UPDATE team_chat_channels SET team_name = $2 WHERE team_name = $1 AND tenant_id = $3`,
+		`UPDATE codeowners_rulesets SET scope_name = $2 WHERE scope = 'team' AND scope_name = $1 AND tenant_id = $3`,
+		`UPDATE org_unit_teams SET team_name = $2 WHERE team_name = $1 AND tenant_id = $3`,
+		`UPDATE pull_requests SET review_team = $2 WHERE review_team = $1 AND tenant_id = $3`,
+	}
+	for _, query := range queries {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, teamName, newTeamName, tenantID(ctx)); err != nil {
+			return fmt.Errorf("failed to rename team: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *teamRepository) DeleteSettings(ctx context.Context, teamName string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	queries := []string{
+		`DELETE FROM team_policies WHERE team_name = $1 AND tenant_id = $2`,
+		`DELETE FROM team_chat_channels WHERE team_name = $1 AND tenant_id = $2`,
+		`DELETE FROM team_memberships WHERE team_name = $1 AND tenant_id = $2`,
+		`DELETE FROM codeowners_rulesets WHERE scope = 'team' AND scope_name = $1 AND tenant_id = $2`,
+		`DELETE FROM org_unit_teams WHERE team_name = $1 AND tenant_id = $2`,
+		`UPDATE pull_requests SET review_team = '' WHERE review_team = $1 AND tenant_id = $2`,
+	}
+	for _, query := range queries {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, query, teamName, tenantID(ctx)); err != nil {
+			return fmt.Errorf("failed to delete team settings: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameMovesPullRequestReviewTeam(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	teams := NewTeamRepository(db)
+
+	require.NoError(t, teams.Rename(domain.WithTenant(context.Background(), "t1"), "backend", "core"))
+
+	q, ok := findQuery(rec.take(), "UPDATE pull_requests SET review_team = $2")
+	require.True(t, ok)
+	assert.Equal(t, "UPDATE pull_requests SET review_team = $2 WHERE review_team = $1 AND tenant_id = $3", normalizeQuery(q.query))
+	assert.Equal(t, []interface{}{"backend", "core", "t1"}, argValues(q.args))
+}
+
+func TestDeleteSettingsClearsPullRequestReviewTeam(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	teams := NewTeamRepository(db)
+
+	require.NoError(t, teams.DeleteSettings(domain.WithTenant(context.Background(), "t1"), "backend"))
+
+	q, ok := findQuery(rec.take(), "UPDATE pull_requests SET review_team = ''")
+	require.True(t, ok)
+	assert.Equal(t, "UPDATE pull_requests SET review_team = '' WHERE review_team = $1 AND tenant_id = $2", normalizeQuery(q.query))
+	assert.Equal(t, []interface{}{"backend", "t1"}, argValues(q.args))
+}
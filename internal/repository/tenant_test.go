@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleTenant = "tenant-under-test"
+
+var repositoryConstructors = map[string]func(*sql.DB) interface{}{
+	"users":         func(db *sql.DB) interface{} { return NewUserRepository(db) },
+	"teams":         func(db *sql.DB) interface{} { return NewTeamRepository(db) },
+	"pullRequests":  func(db *sql.DB) interface{} { return NewPullRequestRepository(db) },
+	"outbox":        func(db *sql.DB) interface{} { return NewOutboxRepository(db) },
+	"webhooks":      func(db *sql.DB) interface{} { return NewWebhookRepository(db) },
+	"integrations":  func(db *sql.DB) interface{} { return NewIntegrationRepository(db) },
+	"codeHostSync":  func(db *sql.DB) interface{} { return NewCodeHostSyncRepository(db) },
+	"notifications": func(db *sql.DB) interface{} { return NewNotificationRepository(db) },
+	"codeowners":    func(db *sql.DB) interface{} { return NewCodeOwnersRepository(db) },
+	"exclusions":    func(db *sql.DB) interface{} { return NewExclusionRepository(db) },
+	"orgUnits":      func(db *sql.DB) interface{} { return NewOrgUnitRepository(db) },
+	"sla":           func(db *sql.DB) interface{} { return NewSLARepository(db) },
+	"stats":         func(db *sql.DB) interface{} { return NewStatsRepository(db) },
+}
+
+func sampleArg(typ reflect.Type, ctx context.Context) reflect.Value {
+	switch {
+	case typ == reflect.TypeOf((*context.Context)(nil)).Elem():
+		return reflect.ValueOf(ctx)
+	case typ == reflect.TypeOf(time.Time{}):
+		return reflect.ValueOf(time.Now())
+	case typ == reflect.TypeOf(time.Duration(0)):
+		return reflect.ValueOf(time.Minute)
+	}
+
+	value := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		value.SetString("sample")
+	case reflect.Int, reflect.Int64:
+		value.SetInt(1)
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Ptr:
+		value.Set(reflect.New(typ.Elem()))
+		value.Elem().Set(sampleArg(typ.Elem(), ctx))
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).IsExported() {
+				value.Field(i).Set(sampleArg(typ.Field(i).Type, ctx))
+			}
+		}
+	case reflect.Slice:
+		value.Set(reflect.MakeSlice(typ, 0, 1))
+		value.Set(reflect.Append(value, sampleArg(typ.Elem(), ctx)))
+	}
+	return value
+}
+
+func TestQueriesFilterByTenant(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	ctx := domain.WithTenant(context.Background(), sampleTenant)
+
+	for name, newRepo := range repositoryConstructors {
+		repo := reflect.ValueOf(newRepo(db))
+		for i := 0; i < repo.NumMethod(); i++ {
+			method := repo.Type().Method(i)
+			call := name + "." + method.Name
+			t.Run(call, func(t *testing.T) {
+				fn := repo.Method(i)
+				args := make([]reflect.Value, fn.Type().NumIn())
+				for j := range args {
+					args[j] = sampleArg(fn.Type().In(j), ctx)
+				}
+				if fn.Type().IsVariadic() {
+					fn.CallSlice(args)
+				} else {
+					fn.Call(args)
+				}
+
+				queries := rec.take()
+				if _, ok := crossTenantCalls[call]; ok {
+					return
+				}
+				require.NotEmpty(t, queries)
+				for _, q := range queries {
+					query := normalizeQuery(q.query)
+					assert.Contains(t, query, "tenant_id", query)
+					assert.Contains(t, argValues(q.args), interface{}(sampleTenant), query)
+				}
+			})
+		}
+	}
+}
+
+func TestCrossTenantCallsExist(t *testing.T) {
+	db, _ := newRecordingDB(t)
+	methods := make(map[string]struct{})
+	for name, newRepo := range repositoryConstructors {
+		repo := reflect.TypeOf(newRepo(db))
+		for i := 0; i < repo.NumMethod(); i++ {
+			methods[fmt.Sprintf("%s.%s", name, repo.Method(i).Name)] = struct{}{}
+		}
+	}
+	for call := range crossTenantCalls {
+		assert.Contains(t, methods, call)
+	}
+}
+
+// crossTenantCalls are the background sweeps that claim work for every tenant;
+// the claimed rows carry their tenant ID, and everything done with them afterwards
+// runs inside that tenant.
+var crossTenantCalls = map[string]string{
+	"users.ListTenantIDs":         "lists tenants for the schedulers",
+	"outbox.ClaimDue":             "outbox fan-out claims events of every tenant",
+	"webhooks.ClaimDueDeliveries": "webhook dispatcher claims deliveries of every tenant",
+	"codeHostSync.ClaimDueJobs":   "code host syncer claims jobs of every tenant",
+	"notifications.ClaimDueJobs":  "notifiers claim jobs of every tenant",
+	"sla.FindBreaches":            "SLA scheduler scans open PRs of every tenant",
+}
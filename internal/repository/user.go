@@ -6,8 +6,14 @@ import (
 	"fmt"
 
 	"avitotest/internal/domain"
+
+	"github.com/lib/pq"
 )
 
+const userColumns = `user_id, username, email, chat_mention, team_name, is_active, seniority, role, archived_at,
+	ARRAY(SELECT m.team_name FROM team_memberships m
+		WHERE m.tenant_id = users.tenant_id AND m.user_id = users.user_id ORDER BY m.team_name)`
+
 type userRepository struct {
 	db *sql.DB
 }
@@ -21,47 +27,79 @@ func (r *userRepository) CreateOrUpdate(ctx context.Context, user *domain.User)
 	defer cancel()
 
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (user_id) 
-		DO UPDATE SET username = $2, team_name = $3, is_active = $4
+		INSERT INTO users (user_id, username, team_name, is_active, seniority, role, tenant_id, email, chat_mention)
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'middle'), COALESCE(NULLIF($6, ''), 'member'), $7, $8, $9)
+		ON CONFLICT (tenant_id, user_id) 
+		DO UPDATE SET username = $2, team_name = $3, is_active = $4,
+			seniority = COALESCE(NULLIF($5, ''), users.seniority),
+			role = COALESCE(NULLIF($6, ''), users.role),
+			email = COALESCE(NULLIF($8, ''), users.email),
+			chat_mention = COALESCE(NULLIF($9, ''), users.chat_mention)
 	`
-	_, err := r.db.ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, user.UserID, user.Username, user.TeamName, user.IsActive, string(user.Seniority), string(user.Role), tenantID(ctx), user.Email, user.ChatMention)
 	if err != nil {
 		return fmt.Errorf("failed to create or update user: %w", err)
 	}
-	return nil
+	if user.TeamName == "" {
+		return nil
+	}
+	return r.AddMembership(ctx, user.UserID, user.TeamName)
 }
 
 func (r *userRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	query := `SELECT user_id, username, team_name, is_active FROM users WHERE user_id = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE user_id = $1 AND tenant_id = $2`
 
-	var user domain.User
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&user.UserID,
-		&user.Username,
-		&user.TeamName,
-		&user.IsActive,
-	)
+	user, err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, query, userID, tenantID(ctx)))
 	if err == sql.ErrNoRows {
 		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
-	return &user, nil
+	return user, nil
+}
+
+func (r *userRepository) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE user_id = ANY($1) AND tenant_id = $2 ORDER BY user_id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(userIDs), tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users: %w", err)
+	}
+
+	return users, nil
 }
 
 func (r *userRepository) GetByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	query := `SELECT user_id, username, team_name, is_active FROM users WHERE team_name = $1`
+	query := `SELECT ` + userColumns + ` FROM users
+		WHERE tenant_id = $2 AND archived_at IS NULL
+			AND user_id IN (SELECT user_id FROM team_memberships WHERE tenant_id = $2 AND team_name = $1)
+		ORDER BY user_id`
 
-	rows, err := r.db.QueryContext(ctx, query, teamName)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, teamName, tenantID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users by team: %w", err)
 	}
@@ -69,11 +107,11 @@ func (r *userRepository) GetByTeamName(ctx context.Context, teamName string) ([]
 
 	var users []*domain.User
 	for rows.Next() {
-		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		user, err := scanUser(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -83,13 +121,52 @@ func (r *userRepository) GetByTeamName(ctx context.Context, teamName string) ([]
 	return users, nil
 }
 
+func (r *userRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	where := `WHERE tenant_id = $3
+		AND ($1 = '' OR user_id IN (SELECT user_id FROM team_memberships WHERE tenant_id = $3 AND team_name = $1)) AND ($2::boolean IS NULL OR is_active = $2) AND archived_at IS NULL`
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM users `+where, filter.TeamName, filter.IsActive, tenantID(ctx)).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users ` + where + `
+		ORDER BY user_id
+		LIMIT $4 OFFSET $5`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, filter.TeamName, filter.IsActive, tenantID(ctx), filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate users: %w", err)
+	}
+
+	return users, total, nil
+}
+
 func (r *userRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	query := `UPDATE users SET is_active = $1 WHERE user_id = $2`
+	query := `UPDATE users SET is_active = $1 WHERE user_id = $2 AND tenant_id = $3`
 
-	result, err := r.db.ExecContext(ctx, query, isActive, userID)
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, isActive, userID, tenantID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to update user activity: %w", err)
 	}
@@ -105,3 +182,159 @@ func (r *userRepository) SetIsActive(ctx context.Context, userID string, isActiv
 
 	return nil
 }
+
+func (r *userRepository) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `UPDATE users SET seniority = $1 WHERE user_id = $2 AND tenant_id = $3`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, string(seniority), userID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update user seniority: %w", err)
+	}
+	return requireAffected(result, "user not found")
+}
+
+func (r *userRepository) SetRole(ctx context.Context, userID string, role domain.TeamRole) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `UPDATE users SET role = $1 WHERE user_id = $2 AND tenant_id = $3`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, string(role), userID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	return requireAffected(result, "user not found")
+}
+
+func (r *userRepository) Archive(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `UPDATE users SET archived_at = NOW(), is_active = false WHERE user_id = $1 AND tenant_id = $2 AND archived_at IS NULL`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, userID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to archive user: %w", err)
+	}
+	return requireAffected(result, "user not found or already archived")
+}
+
+func (r *userRepository) AddMembership(ctx context.Context, userID, teamName string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `INSERT INTO team_memberships (user_id, team_name, tenant_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, userID, teamName, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to add team membership: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) RemoveMembership(ctx context.Context, userID, teamName string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `DELETE FROM team_memberships WHERE user_id = $1 AND team_name = $2 AND tenant_id = $3`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, userID, teamName, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to remove team membership: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) GetTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `SELECT user_id, tag FROM user_tags WHERE user_id = ANY($1) AND tenant_id = $2 ORDER BY user_id, tag`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(userIDs), tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string)
+	for rows.Next() {
+		var userID, tag string
+		if err := rows.Scan(&userID, &tag); err != nil {
+			return nil, fmt.Errorf("failed to scan user tag: %w", err)
+		}
+		tags[userID] = append(tags[userID], tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (r *userRepository) SetTags(ctx context.Context, userID string, tags []string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM user_tags WHERE user_id = $1 AND tenant_id = $2`, userID, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to clear user tags: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_tags (user_id, tag, tenant_id)
+		SELECT $1, UNNEST($2::text[]), $3
+	`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, userID, pq.Array(tags), tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to set user tags: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) ListTenantIDs(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT DISTINCT tenant_id FROM users ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenantIDs []string
+	for rows.Next() {
+		var tenantID string
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tenants: %w", err)
+	}
+
+	return tenantIDs, nil
+}
+
+func scanUser(row rowScanner) (*domain.User, error) {
+	var user domain.User
+	var archivedAt sql.NullTime
+	if err := row.Scan(
+		&user.UserID,
+		&user.Username,
+		&user.Email,
+		&user.ChatMention,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Seniority,
+		&user.Role,
+		&archivedAt,
+		pq.Array(&user.Teams),
+	); err != nil {
+		return nil, err
+	}
+	if archivedAt.Valid {
+		user.ArchivedAt = &archivedAt.Time
+	}
+	return &user, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchivedUsersAreHiddenFromTeamReads(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	users := NewUserRepository(db)
+	teams := NewTeamRepository(db)
+	ctx := domain.WithTenant(context.Background(), "t1")
+
+	reads := map[string]func(){
+		"users.GetByTeamName": func() { _, _ = users.GetByTeamName(ctx, "backend") },
+		"users.List":          func() { _, _, _ = users.List(ctx, domain.UserFilter{TeamName: "backend", Limit: 10}) },
+		"teams.Exists":        func() { _, _ = teams.Exists(ctx, "backend") },
+		"teams.GetByName":     func() { _, _ = teams.GetByName(ctx, "backend") },
+	}
+	for name, read := range reads {
+		t.Run(name, func(t *testing.T) {
+			read()
+			queries := rec.take()
+			require.NotEmpty(t, queries)
+			for _, q := range queries {
+				assert.Contains(t, normalizeQuery(q.query), "archived_at IS NULL")
+			}
+		})
+	}
+}
+
+func TestArchiveSkipsAlreadyArchivedUsers(t *testing.T) {
+	db, rec := newRecordingDB(t)
+	users := NewUserRepository(db)
+
+	require.NoError(t, users.Archive(domain.WithTenant(context.Background(), "t1"), "u1"))
+	queries := rec.take()
+	require.Len(t, queries, 1)
+	query := normalizeQuery(queries[0].query)
+	assert.True(t, strings.HasPrefix(query, "UPDATE users SET archived_at = NOW(), is_active = false"))
+	assert.Contains(t, query, "AND archived_at IS NULL")
+}
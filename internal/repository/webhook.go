@@ -0,0 +1,406 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"avitotest/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) domain.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	eventTypesJSON, err := json.Marshal(sub.EventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event types: %w", err)
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, event_types, secret, is_active, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING subscription_id, created_at
+	`
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, sub.URL, eventTypesJSON, sub.Secret, sub.IsActive, tenantID(ctx)).
+		Scan(&sub.SubscriptionID, &sub.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetSubscription(ctx context.Context, subscriptionID int64) (*domain.WebhookSubscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT subscription_id, url, event_types, secret, is_active, created_at
+		FROM webhook_subscriptions
+		WHERE subscription_id = $1 AND tenant_id = $2
+	`
+
+	sub, err := scanSubscription(conn(ctx, r.db).QueryRowContext(ctx, query, subscriptionID, tenantID(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "webhook subscription not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return sub, nil
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT subscription_id, url, event_types, secret, is_active, created_at
+		FROM webhook_subscriptions
+		WHERE tenant_id = $1
+		ORDER BY subscription_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*domain.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	eventTypesJSON, err := json.Marshal(sub.EventTypes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event types: %w", err)
+	}
+
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $2, event_types = $3, secret = $4, is_active = $5
+		WHERE subscription_id = $1 AND tenant_id = $6
+	`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, sub.SubscriptionID, sub.URL, eventTypesJSON, sub.Secret, sub.IsActive, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return requireAffected(result, "webhook subscription not found")
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `DELETE FROM webhook_subscriptions WHERE subscription_id = $1 AND tenant_id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, subscriptionID, tenantID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return requireAffected(result, "webhook subscription not found")
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, subscriptionID, eventID int64) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, tenant_id)
+		VALUES ($1, $2, $3)
+		RETURNING delivery_id, status, attempts, created_at
+	`
+
+	delivery := domain.WebhookDelivery{SubscriptionID: subscriptionID, EventID: eventID}
+	var status string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, subscriptionID, eventID, tenantID(ctx)).
+		Scan(&delivery.DeliveryID, &status, &delivery.Attempts, &delivery.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	delivery.Status = domain.EventStatus(status)
+	return &delivery, nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT d.delivery_id, d.subscription_id, d.event_id, e.event_type, d.status,
+		       d.attempts, COALESCE(d.last_error, ''), d.created_at, d.delivered_at
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.event_id = d.event_id
+		WHERE d.subscription_id = $1 AND d.tenant_id = $3
+		ORDER BY d.delivery_id DESC
+		LIMIT $2
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, subscriptionID, limit, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		var eventType, status string
+		var deliveredAt sql.NullTime
+		if err := rows.Scan(
+			&delivery.DeliveryID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&eventType,
+			&status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&deliveredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		delivery.EventType = domain.EventType(eventType)
+		delivery.Status = domain.EventStatus(status)
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) ListAttempts(ctx context.Context, deliveryIDs []int64) ([]*domain.DeliveryAttempt, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		SELECT attempt_id, delivery_id, attempted_at, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = ANY($1) AND tenant_id = $2
+		ORDER BY attempt_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(deliveryIDs), tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*domain.DeliveryAttempt
+	for rows.Next() {
+		var attempt domain.DeliveryAttempt
+		if err := rows.Scan(
+			&attempt.AttemptID,
+			&attempt.DeliveryID,
+			&attempt.AttemptedAt,
+			&attempt.StatusCode,
+			&attempt.Error,
+			&attempt.DurationMs,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate delivery attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDeliveryTask, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries
+			SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+			WHERE delivery_id IN (
+				SELECT d.delivery_id
+				FROM webhook_deliveries d
+				JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id
+				WHERE d.status = 'PENDING' AND d.next_attempt_at <= NOW() AND s.is_active
+				ORDER BY d.delivery_id
+				LIMIT $1
+				FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING delivery_id, subscription_id, event_id, attempts, tenant_id
+		)
+		SELECT c.delivery_id, c.subscription_id, c.attempts, s.url, s.secret,
+		       e.event_id, c.tenant_id, e.event_type, e.aggregate_id, e.payload, e.occurred_at
+		FROM claimed c
+		JOIN webhook_subscriptions s ON s.subscription_id = c.subscription_id
+		JOIN outbox_events e ON e.event_id = c.event_id
+		ORDER BY c.delivery_id
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*domain.WebhookDeliveryTask
+	for rows.Next() {
+		var task domain.WebhookDeliveryTask
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(
+			&task.DeliveryID,
+			&task.SubscriptionID,
+			&task.Attempts,
+			&task.URL,
+			&task.Secret,
+			&task.Event.EventID,
+			&task.Event.TenantID,
+			&eventType,
+			&task.Event.AggregateID,
+			&payload,
+			&task.Event.OccurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		task.Event.EventType = domain.EventType(eventType)
+		task.Event.Payload = payload
+		tasks = append(tasks, &task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webhook deliveries: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *webhookRepository) RecordAttempt(ctx context.Context, attempt *domain.DeliveryAttempt) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempted_at, status_code, error, duration_ms, tenant_id)
+		VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''), $5, $6)
+		RETURNING attempt_id
+	`
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		attempt.DeliveryID,
+		attempt.AttemptedAt,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.DurationMs,
+		tenantID(ctx),
+	).Scan(&attempt.AttemptID)
+	if err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) MarkDeliveryDelivered(ctx context.Context, deliveryID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'DELIVERED', attempts = attempts + 1, last_error = NULL, delivered_at = NOW()
+		WHERE delivery_id = $1 AND tenant_id = $2
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, deliveryID, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark webhook delivery delivered: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) MarkDeliveryFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := domain.EventStatusPending
+	if dead {
+		status = domain.EventStatusDead
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE delivery_id = $1 AND tenant_id = $5
+	`
+
+	if _, err := conn(ctx, r.db).ExecContext(ctx, query, deliveryID, string(status), lastError, nextAttemptAt, tenantID(ctx)); err != nil {
+		return fmt.Errorf("failed to mark webhook delivery failed: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var eventTypesJSON []byte
+	if err := row.Scan(
+		&sub.SubscriptionID,
+		&sub.URL,
+		&eventTypesJSON,
+		&sub.Secret,
+		&sub.IsActive,
+		&sub.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventTypesJSON, &sub.EventTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event types: %w", err)
+	}
+	return &sub, nil
+}
+
+func requireAffected(result sql.Result, notFoundMessage string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, notFoundMessage)
+	}
+	return nil
+}
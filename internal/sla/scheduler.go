@@ -0,0 +1,69 @@
+package sla
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type SchedulerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+type Escalator interface {
+	EscalateSLABreach(ctx context.Context, breach *domain.SLABreach) error
+}
+
+type Scheduler struct {
+	slaRepo   domain.SLARepository
+	escalator Escalator
+	cfg       SchedulerConfig
+	logger    *slog.Logger
+}
+
+func NewScheduler(slaRepo domain.SLARepository, escalator Escalator, cfg SchedulerConfig, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		slaRepo:   slaRepo,
+		escalator: escalator,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.CheckBatch(ctx); err != nil {
+				s.logger.Error("sla check failed", "error", err)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) CheckBatch(ctx context.Context) error {
+	breaches, err := s.slaRepo.FindBreaches(ctx, time.Now(), s.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, breach := range breaches {
+		ctx := domain.WithTenant(ctx, breach.TenantID)
+		if err := s.escalator.EscalateSLABreach(ctx, breach); err != nil {
+			s.logger.Error("failed to escalate sla breach",
+				"pull_request_id", breach.PullRequestID,
+				"kind", breach.Kind,
+				"error", err,
+			)
+		}
+	}
+	return nil
+}
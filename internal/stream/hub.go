@@ -0,0 +1,319 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"avitotest/internal/domain"
+)
+
+var EventTypes = []domain.EventType{
+	domain.EventPRCreated,
+	domain.EventReviewerAssigned,
+	domain.EventReviewerReassigned,
+	domain.EventReviewerUnassigned,
+	domain.EventPRMerged,
+	domain.EventPRClosed,
+	domain.EventPRReopened,
+}
+
+type HubConfig struct {
+	QueueSize        int
+	SubscriberBuffer int
+	ReplayPageSize   int
+}
+
+type Filter struct {
+	TeamName string
+	UserID   string
+}
+
+func (f Filter) Matches(msg *Message) bool {
+	if f.TeamName != "" && msg.TeamName != f.TeamName {
+		return false
+	}
+	if f.UserID == "" {
+		return true
+	}
+	for _, userID := range msg.UserIDs {
+		if userID == f.UserID {
+			return true
+		}
+	}
+	return false
+}
+
+type Message struct {
+	Event    *domain.Event
+	TeamName string
+	UserIDs  []string
+}
+
+type hubJob struct {
+	ctx   context.Context
+	event *domain.Event
+}
+
+type subscriber struct {
+	tenantID string
+	filter   Filter
+	messages chan *Message
+}
+
+type Hub struct {
+	userRepo   domain.UserRepository
+	prRepo     domain.PullRequestRepository
+	outboxRepo domain.OutboxRepository
+	cfg        HubConfig
+	queue      chan hubJob
+	logger     *slog.Logger
+
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]*subscriber
+}
+
+func NewHub(
+	userRepo domain.UserRepository,
+	prRepo domain.PullRequestRepository,
+	outboxRepo domain.OutboxRepository,
+	cfg HubConfig,
+	logger *slog.Logger,
+) *Hub {
+	return &Hub{
+		userRepo:    userRepo,
+		prRepo:      prRepo,
+		outboxRepo:  outboxRepo,
+		cfg:         cfg,
+		queue:       make(chan hubJob, cfg.QueueSize),
+		logger:      logger,
+		subscribers: make(map[int]*subscriber),
+	}
+}
+
+func (h *Hub) Handle(ctx context.Context, event *domain.Event) {
+	if !isStreamed(event.EventType) {
+		return
+	}
+
+	select {
+	case h.queue <- hubJob{ctx: ctx, event: event}:
+	default:
+		h.logger.Warn("event stream queue is full, closing tenant streams", "event_id", event.EventID, "tenant_id", event.TenantID)
+		h.dropTenant(event.TenantID)
+	}
+}
+
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-h.queue:
+			if !h.hasSubscribers(job.event.TenantID) {
+				continue
+			}
+			h.broadcast(h.describe(job.ctx, job.event))
+		}
+	}
+}
+
+func (h *Hub) Subscribe(tenantID string, filter Filter) (<-chan *Message, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	sub := &subscriber{
+		tenantID: tenantID,
+		filter:   filter,
+		messages: make(chan *Message, h.cfg.SubscriberBuffer),
+	}
+	h.subscribers[id] = sub
+
+	return sub.messages, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(id)
+	}
+}
+
+func (h *Hub) Replay(ctx context.Context, afterID int64, filter Filter, send func(msg *Message) error) error {
+	for {
+		events, err := h.outboxRepo.ListAfter(ctx, afterID, EventTypes, h.cfg.ReplayPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			afterID = event.EventID
+			msg := h.describe(ctx, event)
+			if !filter.Matches(msg) {
+				continue
+			}
+			if err := send(msg); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < h.cfg.ReplayPageSize {
+			return nil
+		}
+	}
+}
+
+// ReplayedSet remembers the events a stream has replayed so that their live copies
+// are skipped. Only copies still queued in the hub or buffered for the subscriber
+// can arrive after the replay, so it keeps just the most recent IDs that fit there.
+type ReplayedSet struct {
+	window int
+	ids    map[int64]struct{}
+	order  []int64
+}
+
+func (h *Hub) NewReplayedSet() *ReplayedSet {
+	return &ReplayedSet{
+		window: h.cfg.QueueSize + h.cfg.SubscriberBuffer,
+		ids:    make(map[int64]struct{}),
+	}
+}
+
+func (s *ReplayedSet) Add(eventID int64) {
+	s.ids[eventID] = struct{}{}
+	s.order = append(s.order, eventID)
+	if len(s.order) > s.window {
+		delete(s.ids, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// Seen reports whether the event was replayed and forgets it, since each event
+// reaches a subscriber live at most once.
+func (s *ReplayedSet) Seen(eventID int64) bool {
+	if _, ok := s.ids[eventID]; !ok {
+		return false
+	}
+	delete(s.ids, eventID)
+	return true
+}
+
+func (h *Hub) broadcast(msg *Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, sub := range h.subscribers {
+		if sub.tenantID != msg.Event.TenantID || !sub.filter.Matches(msg) {
+			continue
+		}
+		select {
+		case sub.messages <- msg:
+		default:
+			h.logger.Warn("event stream subscriber is too slow, closing stream", "tenant_id", sub.tenantID)
+			h.drop(id)
+		}
+	}
+}
+
+func (h *Hub) hasSubscribers(tenantID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, sub := range h.subscribers {
+		if sub.tenantID == tenantID {
+			return true
+		}
+	}
+	return false
+}
+
+// dropTenant closes every stream of the tenant; clients reconnect with Last-Event-ID
+// and replay what the hub could not queue.
+func (h *Hub) dropTenant(tenantID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, sub := range h.subscribers {
+		if sub.tenantID == tenantID {
+			h.drop(id)
+		}
+	}
+}
+
+func (h *Hub) drop(id int) {
+	sub, ok := h.subscribers[id]
+	if !ok {
+		return
+	}
+	delete(h.subscribers, id)
+	close(sub.messages)
+}
+
+func (h *Hub) describe(ctx context.Context, event *domain.Event) *Message {
+	msg := &Message{Event: event}
+
+	pr, involved, err := h.pullRequest(ctx, event)
+	if err != nil {
+		h.logger.Error("failed to resolve event stream pull request", "event_id", event.EventID, "error", err)
+	}
+	msg.UserIDs = involved
+	if pr == nil {
+		return msg
+	}
+
+	msg.TeamName = pr.ReviewTeam
+	if msg.TeamName == "" && pr.AuthorID != "" {
+		author, err := h.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			h.logger.Error("failed to resolve event stream team", "event_id", event.EventID, "error", err)
+		} else {
+			msg.TeamName = author.TeamName
+		}
+	}
+
+	msg.UserIDs = append(append(pr.AuthorIDs(), pr.AssignedReviewers...), involved...)
+	return msg
+}
+
+func (h *Hub) pullRequest(ctx context.Context, event *domain.Event) (*domain.PullRequest, []string, error) {
+	switch event.EventType {
+	case domain.EventPRCreated, domain.EventPRMerged, domain.EventPRClosed, domain.EventPRReopened:
+		var payload domain.PRStatusChangedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, nil, err
+		}
+		return payload.PullRequest, nil, nil
+	case domain.EventReviewerAssigned:
+		var payload domain.ReviewerAssignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, nil, err
+		}
+		pr, err := h.prRepo.GetByID(ctx, payload.PullRequestID)
+		return pr, []string{payload.ReviewerID}, err
+	case domain.EventReviewerReassigned:
+		var payload domain.ReviewerReassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, nil, err
+		}
+		pr, err := h.prRepo.GetByID(ctx, payload.PullRequestID)
+		return pr, []string{payload.OldReviewerID, payload.NewReviewerID}, err
+	case domain.EventReviewerUnassigned:
+		var payload domain.ReviewerUnassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, nil, err
+		}
+		pr, err := h.prRepo.GetByID(ctx, payload.PullRequestID)
+		return pr, []string{payload.ReviewerID}, err
+	}
+	return nil, nil, nil
+}
+
+func isStreamed(eventType domain.EventType) bool {
+	for _, streamed := range EventTypes {
+		if eventType == streamed {
+			return true
+		}
+	}
+	return false
+}
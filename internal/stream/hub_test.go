@@ -0,0 +1,251 @@
+package stream_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/stream"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamUserRepo struct {
+	domain.UserRepository
+}
+
+func (r *streamUserRepo) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	return &domain.User{UserID: userID, Username: userID, TeamName: "backend"}, nil
+}
+
+type streamPullRequestRepo struct {
+	domain.PullRequestRepository
+	calls atomic.Int32
+}
+
+func (r *streamPullRequestRepo) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	r.calls.Add(1)
+	return &domain.PullRequest{
+		PullRequestID:     prID,
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u3"},
+	}, nil
+}
+
+type streamOutboxRepo struct {
+	domain.OutboxRepository
+	events []*domain.Event
+	calls  int
+}
+
+func (r *streamOutboxRepo) ListAfter(ctx context.Context, afterID int64, eventTypes []domain.EventType, limit int) ([]*domain.Event, error) {
+	r.calls++
+	var page []*domain.Event
+	for _, event := range r.events {
+		if event.EventID > afterID && len(page) < limit {
+			page = append(page, event)
+		}
+	}
+	return page, nil
+}
+
+func newHub(outbox *streamOutboxRepo, subscriberBuffer int) *stream.Hub {
+	return stream.NewHub(
+		&streamUserRepo{},
+		&streamPullRequestRepo{},
+		outbox,
+		stream.HubConfig{QueueSize: 10, SubscriberBuffer: subscriberBuffer, ReplayPageSize: 2},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func startHub(t *testing.T, hub *stream.Hub) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go hub.Run(ctx)
+}
+
+func newEvent(t *testing.T, eventID int64, tenantID string, eventType domain.EventType, payload interface{}) *domain.Event {
+	event, err := domain.NewEvent(eventType, "pr-1", payload)
+	require.NoError(t, err)
+	event.EventID = eventID
+	event.TenantID = tenantID
+	return event
+}
+
+func receive(t *testing.T, messages <-chan *stream.Message) *stream.Message {
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+		return nil
+	}
+}
+
+func assertNoMessage(t *testing.T, messages <-chan *stream.Message) {
+	select {
+	case msg := <-messages:
+		t.Fatalf("unexpected message %d", msg.Event.EventID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHubFiltersByUserAndTenant(t *testing.T) {
+	hub := newHub(&streamOutboxRepo{}, 10)
+	startHub(t, hub)
+
+	messages, unsubscribe := hub.Subscribe("acme", stream.Filter{UserID: "u2"})
+	defer unsubscribe()
+
+	ctx := context.Background()
+	hub.Handle(ctx, newEvent(t, 1, "globex", domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr-1", ReviewerID: "u2"}))
+	hub.Handle(ctx, newEvent(t, 2, "acme", domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr-1", ReviewerID: "u4"}))
+	hub.Handle(ctx, newEvent(t, 3, "acme", domain.EventUserActivityChanged, domain.UserActivityChangedPayload{UserID: "u2"}))
+	hub.Handle(ctx, newEvent(t, 4, "acme", domain.EventReviewerReassigned, domain.ReviewerReassignedPayload{
+		PullRequestID: "pr-1",
+		OldReviewerID: "u2",
+		NewReviewerID: "u4",
+	}))
+
+	msg := receive(t, messages)
+	assert.Equal(t, int64(4), msg.Event.EventID)
+	assert.Equal(t, "backend", msg.TeamName)
+	assertNoMessage(t, messages)
+}
+
+func TestHubFiltersByTeam(t *testing.T) {
+	hub := newHub(&streamOutboxRepo{}, 10)
+	startHub(t, hub)
+
+	backend, unsubscribeBackend := hub.Subscribe("acme", stream.Filter{TeamName: "backend"})
+	defer unsubscribeBackend()
+	payments, unsubscribePayments := hub.Subscribe("acme", stream.Filter{TeamName: "payments"})
+	defer unsubscribePayments()
+
+	ctx := context.Background()
+	hub.Handle(ctx, newEvent(t, 1, "acme", domain.EventPRCreated, domain.PRCreatedPayload{
+		PullRequest: &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1"},
+	}))
+	hub.Handle(ctx, newEvent(t, 2, "acme", domain.EventPRMerged, domain.PRMergedPayload{
+		PullRequest: &domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u1", ReviewTeam: "payments"},
+	}))
+
+	assert.Equal(t, int64(1), receive(t, backend).Event.EventID)
+	assert.Equal(t, int64(2), receive(t, payments).Event.EventID)
+	assertNoMessage(t, backend)
+	assertNoMessage(t, payments)
+}
+
+func TestHubClosesSlowSubscriber(t *testing.T) {
+	hub := newHub(&streamOutboxRepo{}, 1)
+
+	messages, unsubscribe := hub.Subscribe("acme", stream.Filter{})
+	defer unsubscribe()
+
+	ctx := context.Background()
+	for id := int64(1); id <= 3; id++ {
+		hub.Handle(ctx, newEvent(t, id, "acme", domain.EventPRCreated, domain.PRCreatedPayload{
+			PullRequest: &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1"},
+		}))
+	}
+	startHub(t, hub)
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, int64(1), receive(t, messages).Event.EventID)
+	select {
+	case _, ok := <-messages:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("slow subscriber was not closed")
+	}
+}
+
+func TestHubReplayPagesThroughOutbox(t *testing.T) {
+	outbox := &streamOutboxRepo{}
+	for id := int64(1); id <= 5; id++ {
+		reviewerID := "u2"
+		if id%2 == 0 {
+			reviewerID = "u4"
+		}
+		outbox.events = append(outbox.events, newEvent(t, id, "acme", domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{
+			PullRequestID: "pr-1",
+			ReviewerID:    reviewerID,
+		}))
+	}
+	hub := newHub(outbox, 10)
+
+	var replayed []int64
+	err := hub.Replay(context.Background(), 1, stream.Filter{UserID: "u2"}, func(msg *stream.Message) error {
+		replayed = append(replayed, msg.Event.EventID)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{3, 5}, replayed)
+	assert.Equal(t, 3, outbox.calls)
+}
+
+func TestHubClosesTenantStreamsWhenQueueIsFull(t *testing.T) {
+	hub := newHub(&streamOutboxRepo{}, 10)
+
+	acme, unsubscribeAcme := hub.Subscribe("acme", stream.Filter{})
+	defer unsubscribeAcme()
+	globex, unsubscribeGlobex := hub.Subscribe("globex", stream.Filter{})
+	defer unsubscribeGlobex()
+
+	ctx := context.Background()
+	for id := int64(1); id <= 11; id++ {
+		hub.Handle(ctx, newEvent(t, id, "acme", domain.EventPRCreated, domain.PRCreatedPayload{
+			PullRequest: &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1"},
+		}))
+	}
+
+	_, ok := <-acme
+	assert.False(t, ok)
+	select {
+	case <-globex:
+		t.Fatal("stream of another tenant was closed")
+	default:
+	}
+}
+
+func TestHubSkipsEventsWithoutSubscribers(t *testing.T) {
+	prRepo := &streamPullRequestRepo{}
+	hub := stream.NewHub(
+		&streamUserRepo{},
+		prRepo,
+		&streamOutboxRepo{},
+		stream.HubConfig{QueueSize: 10, SubscriberBuffer: 10, ReplayPageSize: 2},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	startHub(t, hub)
+
+	messages, unsubscribe := hub.Subscribe("acme", stream.Filter{})
+	defer unsubscribe()
+
+	ctx := context.Background()
+	hub.Handle(ctx, newEvent(t, 1, "globex", domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr-1", ReviewerID: "u2"}))
+	hub.Handle(ctx, newEvent(t, 2, "acme", domain.EventReviewerAssigned, domain.ReviewerAssignedPayload{PullRequestID: "pr-1", ReviewerID: "u2"}))
+
+	assert.Equal(t, int64(2), receive(t, messages).Event.EventID)
+	assert.Equal(t, int32(1), prRepo.calls.Load())
+}
+
+func TestReplayedSetKeepsRecentEvents(t *testing.T) {
+	replayed := newHub(&streamOutboxRepo{}, 2).NewReplayedSet()
+	for id := int64(1); id <= 20; id++ {
+		replayed.Add(id)
+	}
+
+	assert.False(t, replayed.Seen(8))
+	assert.True(t, replayed.Seen(9))
+	assert.True(t, replayed.Seen(20))
+	assert.False(t, replayed.Seen(20))
+}
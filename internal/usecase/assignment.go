@@ -0,0 +1,508 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type assignmentPlan struct {
+	coAuthorIDs []string
+	reviewTeam  string
+	labels      []string
+	reviewers   []string
+	explanation *domain.AssignmentExplanation
+}
+
+func (uc *PullRequestUseCase) planAssignment(ctx context.Context, input CreatePullRequestInput, rng *rand.Rand) (*assignmentPlan, error) {
+	author, err := uc.userRepo.GetByID(ctx, input.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewTeam := author.TeamName
+	if input.ReviewTeam != "" {
+		exists, err := uc.teamRepo.Exists(ctx, input.ReviewTeam)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "review team not found")
+		}
+		reviewTeam = input.ReviewTeam
+	}
+
+	authors := []string{author.UserID}
+	teamNames := []string{reviewTeam}
+	var coAuthorIDs []string
+	for _, coAuthorID := range input.CoAuthorIDs {
+		if coAuthorID == "" || containsString(authors, coAuthorID) {
+			continue
+		}
+		coAuthor, err := uc.userRepo.GetByID(ctx, coAuthorID)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, coAuthorID)
+		coAuthorIDs = append(coAuthorIDs, coAuthorID)
+		if input.ReviewTeam == "" && !containsString(teamNames, coAuthor.TeamName) {
+			teamNames = append(teamNames, coAuthor.TeamName)
+		}
+	}
+
+	var teamUsers []*domain.User
+	for _, teamName := range teamNames {
+		users, err := uc.userRepo.GetByTeamName(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		teamUsers = append(teamUsers, users...)
+	}
+
+	owners, err := uc.codeOwners.ResolveOwners(ctx, input.Repository, reviewTeam, input.ChangedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded, err := uc.excludedReviewers(ctx, authors)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := normalizeTags(input.Labels)
+	all := excludeUsers(append(append([]*domain.User{}, owners...), teamUsers...), nil)
+	explanation := &domain.AssignmentExplanation{
+		Strategy:   strategyFor(labels),
+		PoolSize:   len(all),
+		AssignedAt: time.Now(),
+	}
+
+	eligible := recordFilter(explanation, "author", all, func(user *domain.User) bool {
+		return containsString(authors, user.UserID)
+	})
+	eligible = recordFilter(explanation, "inactive", eligible, func(user *domain.User) bool {
+		return !user.IsActive
+	})
+	eligible = recordFilter(explanation, "exclusion", eligible, func(user *domain.User) bool {
+		_, ok := excluded[user.UserID]
+		return ok
+	})
+	explanation.EligibleCount = len(eligible)
+
+	isEligible := func(user *domain.User) bool { return containsUser(eligible, user.UserID) }
+	ownerCandidates := filterUsers(owners, isEligible)
+	candidates := filterUsers(teamUsers, isEligible)
+
+	scores, err := uc.expertiseScores(ctx, labels, eligible)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.teamRepo.GetPolicy(ctx, reviewTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[string]domain.AssignmentReason)
+	reviewers := []string{}
+	note := func(reason domain.AssignmentReason) {
+		for _, reviewerID := range reviewers {
+			if _, ok := reasons[reviewerID]; !ok {
+				reasons[reviewerID] = reason
+			}
+		}
+	}
+
+	if policy.RequiresLead(labels) {
+		leads := filterUsers(candidates, func(user *domain.User) bool {
+			return user.Role == domain.TeamRoleLead && user.TeamName == reviewTeam
+		})
+		if len(leads) == 0 {
+			return nil, domain.NewDomainError(domain.ErrorCodeNoCandidate, "team policy requires a lead reviewer, none available")
+		}
+		reviewers = uc.selectReviewers(rng, leads, 1, scores)
+		note(domain.AssignmentReasonLead)
+	}
+	reserved := len(reviewers)
+
+	reviewers = append(reviewers, uc.selectReviewers(rng, excludeUsers(ownerCandidates, reviewers), maxReviewers-len(reviewers), scores)...)
+	note(domain.AssignmentReasonCodeOwner)
+	if len(reviewers) < maxReviewers {
+		rest := excludeUsers(candidates, reviewers)
+		reviewers = append(reviewers, uc.selectReviewers(rng, rest, maxReviewers-len(reviewers), scores)...)
+		note(domain.AssignmentReasonTeam)
+	}
+	reviewers = uc.ensureExpert(rng, reviewers, reserved, eligible, scores)
+	note(domain.AssignmentReasonExpert)
+
+	var fallback []*domain.User
+	fallbackLoaded := false
+	loadFallback := func() error {
+		if fallbackLoaded {
+			return nil
+		}
+		fallbackLoaded = true
+		department, err := uc.departmentPool(ctx, reviewTeam)
+		if err != nil {
+			return err
+		}
+		fallback = filterUsers(excludeUsers(department, nil), func(user *domain.User) bool {
+			_, isExcluded := excluded[user.UserID]
+			return user.IsActive && !isExcluded && !containsString(authors, user.UserID) && !containsUser(eligible, user.UserID)
+		})
+		explanation.FallbackPoolSize = len(fallback)
+
+		fallbackScores, err := uc.expertiseScores(ctx, labels, fallback)
+		if err != nil {
+			return err
+		}
+		for userID, score := range fallbackScores {
+			if scores == nil {
+				scores = make(map[string]int)
+			}
+			scores[userID] = score
+		}
+		return nil
+	}
+	noteFallback := func(reason domain.AssignmentReason) {
+		for _, reviewerID := range reviewers {
+			if _, ok := reasons[reviewerID]; !ok && containsUser(fallback, reviewerID) {
+				reasons[reviewerID] = domain.AssignmentReasonDepartment
+			}
+		}
+		note(reason)
+	}
+
+	// Seniors the team lacks may still come from the department before the policy gives up.
+	withSeniors, err := uc.ensureSeniors(rng, reviewers, reserved, eligible, policy.MinSeniorReviewers, scores)
+	if hasErrorCode(err, domain.ErrorCodeNoCandidate) {
+		if err := loadFallback(); err != nil {
+			return nil, err
+		}
+		pool := append(append([]*domain.User{}, eligible...), fallback...)
+		withSeniors, err = uc.ensureSeniors(rng, reviewers, reserved, pool, policy.MinSeniorReviewers, scores)
+	}
+	if err != nil {
+		return nil, err
+	}
+	reviewers = withSeniors
+	noteFallback(domain.AssignmentReasonSenior)
+
+	if len(reviewers) < maxReviewers {
+		if err := loadFallback(); err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, uc.selectReviewers(rng, excludeUsers(fallback, reviewers), maxReviewers-len(reviewers), scores)...)
+		noteFallback(domain.AssignmentReasonDepartment)
+	}
+
+	explanation.Reviewers = make([]domain.ReviewerChoice, 0, len(reviewers))
+	for _, reviewerID := range reviewers {
+		explanation.Reviewers = append(explanation.Reviewers, domain.ReviewerChoice{
+			UserID: reviewerID,
+			Reason: reasons[reviewerID],
+			Score:  scores[reviewerID],
+		})
+	}
+
+	return &assignmentPlan{
+		coAuthorIDs: coAuthorIDs,
+		reviewTeam:  input.ReviewTeam,
+		labels:      labels,
+		reviewers:   reviewers,
+		explanation: explanation,
+	}, nil
+}
+
+func strategyFor(labels []string) domain.AssignmentStrategy {
+	if len(labels) > 0 {
+		return domain.AssignmentStrategyExpertise
+	}
+	return domain.AssignmentStrategyRandom
+}
+
+func recordFilter(explanation *domain.AssignmentExplanation, name string, users []*domain.User, drop func(*domain.User) bool) []*domain.User {
+	filter := domain.AssignmentFilter{Name: name, Removed: []string{}}
+	var kept []*domain.User
+	for _, user := range users {
+		if drop(user) {
+			filter.Removed = append(filter.Removed, user.UserID)
+			continue
+		}
+		kept = append(kept, user)
+	}
+	explanation.Filters = append(explanation.Filters, filter)
+	return kept
+}
+
+func (uc *PullRequestUseCase) expertiseScores(ctx context.Context, labels []string, users []*domain.User) (map[string]int, error) {
+	if len(labels) == 0 || len(users) == 0 {
+		return nil, nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.UserID)
+	}
+	tags, err := uc.userRepo.GetTags(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		wanted[label] = struct{}{}
+	}
+
+	scores := make(map[string]int, len(tags))
+	for userID, userTags := range tags {
+		for _, tag := range userTags {
+			if _, ok := wanted[tag]; ok {
+				scores[userID]++
+			}
+		}
+	}
+	return scores, nil
+}
+
+func (uc *PullRequestUseCase) departmentPool(ctx context.Context, teamName string) ([]*domain.User, error) {
+	unitID, err := uc.orgUnitRepo.GetUnitIDByTeam(ctx, teamName)
+	if hasErrorCode(err, domain.ErrorCodeNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	teamNames, err := uc.orgUnitRepo.GetSubtreeTeams(ctx, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*domain.User
+	for _, name := range teamNames {
+		if name == teamName {
+			continue
+		}
+		teamUsers, err := uc.userRepo.GetByTeamName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, teamUsers...)
+	}
+	return users, nil
+}
+
+func (uc *PullRequestUseCase) excludedReviewers(ctx context.Context, authorIDs []string) (map[string]struct{}, error) {
+	userIDs, err := uc.exclusionRepo.GetExcludedReviewers(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		excluded[userID] = struct{}{}
+	}
+	return excluded, nil
+}
+
+func (uc *PullRequestUseCase) ensureExpert(rng *rand.Rand, reviewers []string, reserved int, pool []*domain.User, scores map[string]int) []string {
+	for _, reviewerID := range reviewers {
+		if scores[reviewerID] > 0 {
+			return reviewers
+		}
+	}
+
+	var experts []*domain.User
+	for _, user := range excludeUsers(pool, reviewers) {
+		if scores[user.UserID] > 0 {
+			experts = append(experts, user)
+		}
+	}
+	if len(experts) == 0 {
+		return reviewers
+	}
+
+	expert := uc.selectReviewers(rng, experts, 1, scores)[0]
+	if len(reviewers) < maxReviewers {
+		return append(reviewers, expert)
+	}
+	if len(reviewers) <= reserved {
+		return reviewers
+	}
+	reviewers[len(reviewers)-1] = expert
+	return reviewers
+}
+
+func (uc *PullRequestUseCase) ensureSeniors(rng *rand.Rand, reviewers []string, reserved int, pool []*domain.User, minSeniors int, scores map[string]int) ([]string, error) {
+	if minSeniors <= 0 {
+		return reviewers, nil
+	}
+
+	seniority := make(map[string]domain.Seniority, len(pool))
+	for _, user := range pool {
+		seniority[user.UserID] = user.Seniority
+	}
+
+	chosen := 0
+	for _, reviewerID := range reviewers {
+		if seniority[reviewerID] == domain.SenioritySenior {
+			chosen++
+		}
+	}
+	if chosen >= minSeniors {
+		return reviewers, nil
+	}
+
+	// Experts go last so the expertise slot is only given up when nothing else can make room.
+	var replaceable []int
+	for _, expert := range []bool{false, true} {
+		for i := len(reviewers) - 1; i >= reserved; i-- {
+			if seniority[reviewers[i]] != domain.SenioritySenior && (scores[reviewers[i]] > 0) == expert {
+				replaceable = append(replaceable, i)
+			}
+		}
+	}
+	seniors := filterUsers(excludeUsers(pool, reviewers), func(user *domain.User) bool {
+		return user.Seniority == domain.SenioritySenior
+	})
+	if chosen+len(seniors) < minSeniors || chosen+maxReviewers-len(reviewers)+len(replaceable) < minSeniors {
+		return nil, domain.NewDomainError(domain.ErrorCodeNoCandidate, fmt.Sprintf("team policy requires at least %d senior reviewers", minSeniors))
+	}
+
+	for _, seniorID := range uc.selectReviewers(rng, seniors, minSeniors-chosen, scores) {
+		if len(reviewers) < maxReviewers {
+			reviewers = append(reviewers, seniorID)
+			continue
+		}
+		reviewers[replaceable[0]] = seniorID
+		replaceable = replaceable[1:]
+	}
+	return reviewers, nil
+}
+
+func (uc *PullRequestUseCase) applyTeamPolicy(ctx context.Context, pr *domain.PullRequest, oldUserID string, candidates []*domain.User) ([]*domain.User, error) {
+	teamName, err := uc.pullRequestTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := uc.teamRepo.GetPolicy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	needLead := policy.RequiresLead(pr.Labels)
+	if policy.MinSeniorReviewers <= 0 && !needLead {
+		return candidates, nil
+	}
+
+	seniors, hasLead := 0, false
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == oldUserID {
+			continue
+		}
+		reviewer, err := uc.userRepo.GetByID(ctx, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if reviewer.Seniority == domain.SenioritySenior {
+			seniors++
+		}
+		if reviewer.Role == domain.TeamRoleLead {
+			hasLead = true
+		}
+	}
+
+	if seniors < policy.MinSeniorReviewers {
+		candidates = filterUsers(candidates, func(user *domain.User) bool { return user.Seniority == domain.SenioritySenior })
+		if len(candidates) == 0 {
+			return nil, domain.NewDomainError(domain.ErrorCodeNoCandidate, "team policy requires a senior replacement, none available")
+		}
+	}
+	if needLead && !hasLead {
+		candidates = filterUsers(candidates, func(user *domain.User) bool { return user.Role == domain.TeamRoleLead })
+		if len(candidates) == 0 {
+			return nil, domain.NewDomainError(domain.ErrorCodeNoCandidate, "team policy requires a lead replacement, none available")
+		}
+	}
+	return candidates, nil
+}
+
+func (uc *PullRequestUseCase) selectReviewers(rng *rand.Rand, candidates []*domain.User, maxCount int, scores map[string]int) []string {
+	if len(candidates) == 0 {
+		return []string{}
+	}
+
+	count := maxCount
+	if len(candidates) < count {
+		count = len(candidates)
+	}
+
+	shuffled := make([]*domain.User, len(candidates))
+	copy(shuffled, candidates)
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	sort.SliceStable(shuffled, func(i, j int) bool {
+		return scores[shuffled[i].UserID] > scores[shuffled[j].UserID]
+	})
+
+	reviewers := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		reviewers = append(reviewers, shuffled[i].UserID)
+	}
+
+	return reviewers
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUser(users []*domain.User, userID string) bool {
+	for _, user := range users {
+		if user.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func filterUsers(users []*domain.User, keep func(*domain.User) bool) []*domain.User {
+	var kept []*domain.User
+	for _, user := range users {
+		if keep(user) {
+			kept = append(kept, user)
+		}
+	}
+	return kept
+}
+
+func excludeUsers(users []*domain.User, userIDs []string) []*domain.User {
+	excluded := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		excluded[userID] = struct{}{}
+	}
+
+	var rest []*domain.User
+	seen := make(map[string]struct{}, len(users))
+	for _, user := range users {
+		if _, ok := excluded[user.UserID]; ok {
+			continue
+		}
+		if _, ok := seen[user.UserID]; ok {
+			continue
+		}
+		seen[user.UserID] = struct{}{}
+		rest = append(rest, user)
+	}
+	return rest
+}
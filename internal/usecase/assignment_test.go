@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureSeniorsReplacesNonSeniors(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{
+		{UserID: "j1", Seniority: domain.SeniorityJunior},
+		{UserID: "j2", Seniority: domain.SeniorityJunior},
+		{UserID: "s1", Seniority: domain.SenioritySenior},
+	}
+
+	reviewers, err := uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"j1", "j2"}, 0, pool, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"j1", "s1"}, reviewers)
+
+	reviewers, err = uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"j1"}, 0, pool, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"j1", "s1"}, reviewers)
+
+	reviewers, err = uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"s1", "j1"}, 0, pool, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1", "j1"}, reviewers)
+}
+
+func TestEnsureSeniorsFailsWithoutSeniors(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{
+		{UserID: "j1", Seniority: domain.SeniorityJunior},
+		{UserID: "s1", Seniority: domain.SenioritySenior},
+	}
+
+	_, err := uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"j1", "s1"}, 0, pool, 2, nil)
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNoCandidate, domainErr.Code)
+}
+
+func TestEnsureSeniorsKeepsReservedLead(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{
+		{UserID: "lead", Seniority: domain.SeniorityMiddle, Role: domain.TeamRoleLead},
+		{UserID: "j1", Seniority: domain.SeniorityJunior},
+		{UserID: "s1", Seniority: domain.SenioritySenior},
+		{UserID: "s2", Seniority: domain.SenioritySenior},
+	}
+
+	reviewers, err := uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"lead", "j1"}, 1, pool, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "lead", reviewers[0])
+	assert.Contains(t, []string{"s1", "s2"}, reviewers[1])
+
+	_, err = uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"lead", "j1"}, 1, pool, 2, nil)
+	require.Error(t, err)
+}
+
+func TestEnsureSeniorsKeepsExpertSlot(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{
+		{UserID: "j1", Seniority: domain.SeniorityJunior},
+		{UserID: "e1", Seniority: domain.SeniorityJunior},
+		{UserID: "s1", Seniority: domain.SenioritySenior},
+	}
+	scores := map[string]int{"e1": 1}
+
+	reviewers, err := uc.ensureSeniors(rand.New(rand.NewSource(1)), []string{"j1", "e1"}, 0, pool, 1, scores)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1", "e1"}, reviewers)
+}
+
+func TestEnsureSeniorsPrefersSeniorExpertWhenExpertSlotIsTaken(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{
+		{UserID: "lead", Seniority: domain.SeniorityMiddle, Role: domain.TeamRoleLead},
+		{UserID: "e1", Seniority: domain.SeniorityJunior},
+		{UserID: "s1", Seniority: domain.SenioritySenior},
+		{UserID: "s2", Seniority: domain.SenioritySenior},
+	}
+	scores := map[string]int{"e1": 1, "s2": 1}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		reviewers, err := uc.ensureSeniors(rand.New(rand.NewSource(seed)), []string{"lead", "e1"}, 1, pool, 1, scores)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"lead", "s2"}, reviewers)
+	}
+}
+
+func TestPlanAssignmentKeepsExpertWithSeniorPolicy(t *testing.T) {
+	dir := newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("j1", "owners", domain.SeniorityJunior),
+		member("j2", "owners", domain.SeniorityJunior),
+		member("j3", "backend", domain.SeniorityJunior),
+		member("s1", "backend", domain.SenioritySenior),
+	)
+	dir.codeOwners = "* @acme/owners"
+	dir.tags["j3"] = []string{"db"}
+	dir.policies["backend"] = &domain.TeamPolicy{TeamName: "backend", MinSeniorReviewers: 1}
+	uc := newDirectoryUseCase(dir)
+
+	for seed := int64(1); seed <= 50; seed++ {
+		plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{
+			PullRequestID: "pr1",
+			AuthorID:      "author",
+			Labels:        []string{"db"},
+			ChangedFiles:  []string{"internal/db/pool.go"},
+		}, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"j3", "s1"}, plan.reviewers, "seed %d", seed)
+	}
+}
+
+func TestEnsureExpertAppendsWhenThereIsRoom(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{{UserID: "u1"}, {UserID: "e1"}, {UserID: "e2"}}
+	scores := map[string]int{"e1": 1, "e2": 2}
+
+	reviewers := uc.ensureExpert(rand.New(rand.NewSource(1)), []string{"u1"}, 0, pool, scores)
+	assert.Equal(t, []string{"u1", "e2"}, reviewers)
+}
+
+func TestEnsureExpertReplacesLastReviewerWhenFull(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "e1"}}
+	scores := map[string]int{"e1": 1}
+
+	reviewers := uc.ensureExpert(rand.New(rand.NewSource(1)), []string{"u1", "u2"}, 0, pool, scores)
+	assert.Equal(t, []string{"u1", "e1"}, reviewers)
+}
+
+func TestEnsureExpertKeepsExistingExpert(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{{UserID: "u1"}, {UserID: "e1"}, {UserID: "e2"}}
+	scores := map[string]int{"e1": 1, "e2": 3}
+
+	reviewers := uc.ensureExpert(rand.New(rand.NewSource(1)), []string{"e1", "u1"}, 0, pool, scores)
+	assert.Equal(t, []string{"e1", "u1"}, reviewers)
+
+	reviewers = uc.ensureExpert(rand.New(rand.NewSource(1)), []string{"u1"}, 0, pool, nil)
+	assert.Equal(t, []string{"u1"}, reviewers)
+}
+
+func TestEnsureExpertKeepsReservedLead(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	pool := []*domain.User{{UserID: "lead", Role: domain.TeamRoleLead}, {UserID: "u1"}, {UserID: "e1"}}
+	scores := map[string]int{"e1": 1}
+
+	reviewers := uc.ensureExpert(rand.New(rand.NewSource(1)), []string{"lead", "u1"}, 1, pool, scores)
+	assert.Equal(t, []string{"lead", "e1"}, reviewers)
+
+	pool = append(pool, &domain.User{UserID: "lead2", Role: domain.TeamRoleLead})
+	reviewers = uc.ensureExpert(rand.New(rand.NewSource(1)), []string{"lead", "lead2"}, 2, pool, scores)
+	assert.Equal(t, []string{"lead", "lead2"}, reviewers)
+}
+
+func TestSelectReviewersIsReproducibleForSeed(t *testing.T) {
+	uc := &PullRequestUseCase{}
+	var candidates []*domain.User
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5", "u6"} {
+		candidates = append(candidates, &domain.User{UserID: id})
+	}
+
+	first := uc.selectReviewers(rand.New(rand.NewSource(42)), candidates, 2, nil)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, uc.selectReviewers(rand.New(rand.NewSource(42)), candidates, 2, nil))
+	}
+}
+
+func TestSelectReviewersIsFair(t *testing.T) {
+	uc := &PullRequestUseCase{random: NewRandom(7)}
+	var candidates []*domain.User
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		candidates = append(candidates, &domain.User{UserID: id})
+	}
+
+	const rounds = 50000
+	counts := make(map[string]int)
+	for i := 0; i < rounds; i++ {
+		for _, reviewerID := range uc.selectReviewers(uc.random, candidates, 2, nil) {
+			counts[reviewerID]++
+		}
+	}
+
+	expected := float64(rounds*2) / float64(len(candidates))
+	chiSquare := 0.0
+	for _, candidate := range candidates {
+		diff := float64(counts[candidate.UserID]) - expected
+		chiSquare += diff * diff / expected
+	}
+	// 4 degrees of freedom, p = 0.001
+	assert.Less(t, chiSquare, 18.47, "selection counts %v", counts)
+}
+
+func TestRandomIsSafeForConcurrentUse(t *testing.T) {
+	uc := &PullRequestUseCase{random: NewRandom(1)}
+	var candidates []*domain.User
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		candidates = append(candidates, &domain.User{UserID: id})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				uc.selectReviewers(uc.random, candidates, 2, nil)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPreviewAssignmentIsReproducibleForSeed(t *testing.T) {
+	dir := newDirectory(member("author", "backend", domain.SeniorityMiddle))
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		dir.users[id] = member(id, "backend", domain.SeniorityMiddle)
+	}
+	uc := newDirectoryUseCase(dir)
+	input := CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}
+
+	first, err := uc.PreviewAssignment(context.Background(), input, nil)
+	require.NoError(t, err)
+	require.NotNil(t, first.Assignment.Seed)
+
+	for i := 0; i < 10; i++ {
+		again, err := uc.PreviewAssignment(context.Background(), input, first.Assignment.Seed)
+		require.NoError(t, err)
+		assert.Equal(t, first.AssignedReviewers, again.AssignedReviewers)
+		assert.Equal(t, *first.Assignment.Seed, *again.Assignment.Seed)
+	}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanAssignmentExcludesCoAuthorsFromCandidates(t *testing.T) {
+	uc := newDirectoryUseCase(newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("co", "backend", domain.SeniorityMiddle),
+		member("c", "backend", domain.SeniorityMiddle),
+		member("d", "backend", domain.SeniorityMiddle),
+	))
+
+	for seed := int64(1); seed <= 20; seed++ {
+		plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{
+			PullRequestID: "pr1",
+			AuthorID:      "author",
+			CoAuthorIDs:   []string{"co"},
+		}, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"c", "d"}, plan.reviewers)
+		assert.ElementsMatch(t, []string{"author", "co"}, plan.explanation.RemovedBy("author"))
+		assert.Equal(t, []string{"co"}, plan.coAuthorIDs)
+	}
+}
+
+func TestPlanAssignmentMergesCoAuthorTeamPools(t *testing.T) {
+	uc := newDirectoryUseCase(newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("b1", "backend", domain.SeniorityMiddle),
+		member("co", "frontend", domain.SeniorityMiddle),
+		member("f1", "frontend", domain.SeniorityMiddle),
+	))
+
+	plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{
+		PullRequestID: "pr1",
+		AuthorID:      "author",
+		CoAuthorIDs:   []string{"co"},
+	}, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"b1", "f1"}, plan.reviewers)
+	assert.Equal(t, 4, plan.explanation.PoolSize)
+	assert.Empty(t, plan.reviewTeam)
+}
+
+func TestPlanAssignmentExplicitReviewTeamIgnoresCoAuthorTeams(t *testing.T) {
+	uc := newDirectoryUseCase(newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("b1", "backend", domain.SeniorityMiddle),
+		member("co", "frontend", domain.SeniorityMiddle),
+		member("f1", "frontend", domain.SeniorityMiddle),
+	))
+
+	plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{
+		PullRequestID: "pr1",
+		AuthorID:      "author",
+		CoAuthorIDs:   []string{"co"},
+		ReviewTeam:    "backend",
+	}, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"b1"}, plan.reviewers)
+	assert.Equal(t, 2, plan.explanation.PoolSize)
+	assert.Equal(t, "backend", plan.reviewTeam)
+}
+
+func TestPlanAssignmentTakesLeadFromReviewTeamOnly(t *testing.T) {
+	dir := newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("b1", "backend", domain.SeniorityMiddle),
+		member("co", "frontend", domain.SeniorityMiddle),
+		lead("flead", "frontend", domain.SenioritySenior),
+	)
+	dir.policies["backend"] = &domain.TeamPolicy{TeamName: "backend", LeadReviewLabels: []string{"security"}}
+	uc := newDirectoryUseCase(dir)
+	input := CreatePullRequestInput{
+		PullRequestID: "pr1",
+		AuthorID:      "author",
+		CoAuthorIDs:   []string{"co"},
+		Labels:        []string{"security"},
+	}
+
+	_, err := uc.planAssignment(context.Background(), input, rand.New(rand.NewSource(1)))
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNoCandidate, domainErr.Code)
+
+	dir.users["blead"] = lead("blead", "backend", domain.SenioritySenior)
+	for seed := int64(1); seed <= 20; seed++ {
+		plan, err := uc.planAssignment(context.Background(), input, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+
+		require.NotEmpty(t, plan.explanation.Reviewers)
+		assert.Equal(t, domain.ReviewerChoice{UserID: "blead", Reason: domain.AssignmentReasonLead}, plan.explanation.Reviewers[0])
+		for _, choice := range plan.explanation.Reviewers[1:] {
+			assert.NotEqual(t, domain.AssignmentReasonLead, choice.Reason)
+		}
+	}
+}
+
+func TestPlanAssignmentDeduplicatesCoAuthors(t *testing.T) {
+	dir := newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("co", "frontend", domain.SeniorityMiddle),
+		member("shared", "backend", domain.SeniorityMiddle),
+	)
+	dir.users["shared"].Teams = []string{"backend", "frontend"}
+	uc := newDirectoryUseCase(dir)
+
+	for seed := int64(1); seed <= 20; seed++ {
+		plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{
+			PullRequestID: "pr1",
+			AuthorID:      "author",
+			CoAuthorIDs:   []string{"co", "", "author", "co"},
+		}, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"co"}, plan.coAuthorIDs)
+		assert.Equal(t, []string{"shared"}, plan.reviewers, "a member of both merged teams is a single candidate")
+		assert.Equal(t, 3, plan.explanation.PoolSize)
+	}
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"avitotest/internal/domain"
+)
+
+type CodeOwnersUseCase struct {
+	codeOwnersRepo  domain.CodeOwnersRepository
+	userRepo        domain.UserRepository
+	integrationRepo domain.IntegrationRepository
+}
+
+func NewCodeOwnersUseCase(
+	codeOwnersRepo domain.CodeOwnersRepository,
+	userRepo domain.UserRepository,
+	integrationRepo domain.IntegrationRepository,
+) *CodeOwnersUseCase {
+	return &CodeOwnersUseCase{
+		codeOwnersRepo:  codeOwnersRepo,
+		userRepo:        userRepo,
+		integrationRepo: integrationRepo,
+	}
+}
+
+func (uc *CodeOwnersUseCase) SetRuleset(ctx context.Context, ruleset *domain.CodeOwnersRuleset) (*domain.CodeOwnersRuleset, error) {
+	if ruleset.Scope != domain.CodeOwnersScopeTeam && ruleset.Scope != domain.CodeOwnersScopeRepository {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "scope must be team or repository")
+	}
+	if ruleset.ScopeName == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "scope_name is required")
+	}
+	if _, err := parseCodeOwners(ruleset.Content); err != nil {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, err.Error())
+	}
+
+	if err := uc.codeOwnersRepo.Upsert(ctx, ruleset); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
+
+func (uc *CodeOwnersUseCase) GetRuleset(ctx context.Context, scope domain.CodeOwnersScope, scopeName string) (*domain.CodeOwnersRuleset, error) {
+	return uc.codeOwnersRepo.Get(ctx, scope, scopeName)
+}
+
+func (uc *CodeOwnersUseCase) ResolveOwners(ctx context.Context, repository, teamName string, changedFiles []string) ([]*domain.User, error) {
+	if len(changedFiles) == 0 {
+		return nil, nil
+	}
+
+	ruleset, err := uc.findRuleset(ctx, repository, teamName)
+	if err != nil || ruleset == nil {
+		return nil, err
+	}
+
+	rules, err := parseCodeOwners(ruleset.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []string
+	seenOwners := make(map[string]struct{})
+	for _, path := range changedFiles {
+		for _, owner := range rules.ownersOf(path) {
+			if _, ok := seenOwners[owner]; !ok {
+				seenOwners[owner] = struct{}{}
+				owners = append(owners, owner)
+			}
+		}
+	}
+
+	var users []*domain.User
+	seenUsers := make(map[string]struct{})
+	for _, owner := range owners {
+		resolved, err := uc.resolveOwner(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range resolved {
+			if _, ok := seenUsers[user.UserID]; !ok {
+				seenUsers[user.UserID] = struct{}{}
+				users = append(users, user)
+			}
+		}
+	}
+	return users, nil
+}
+
+func (uc *CodeOwnersUseCase) findRuleset(ctx context.Context, repository, teamName string) (*domain.CodeOwnersRuleset, error) {
+	scopes := []struct {
+		scope domain.CodeOwnersScope
+		name  string
+	}{
+		{domain.CodeOwnersScopeRepository, repository},
+		{domain.CodeOwnersScopeTeam, teamName},
+	}
+
+	for _, s := range scopes {
+		if s.name == "" {
+			continue
+		}
+		ruleset, err := uc.codeOwnersRepo.Get(ctx, s.scope, s.name)
+		if err == nil {
+			return ruleset, nil
+		}
+		if !hasErrorCode(err, domain.ErrorCodeNotFound) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (uc *CodeOwnersUseCase) resolveOwner(ctx context.Context, owner string) ([]*domain.User, error) {
+	if !strings.HasPrefix(owner, "@") {
+		return nil, nil
+	}
+	name := strings.TrimPrefix(owner, "@")
+
+	if slash := strings.Index(name, "/"); slash >= 0 {
+		return uc.userRepo.GetByTeamName(ctx, name[slash+1:])
+	}
+
+	userID := name
+	for _, provider := range []domain.CodeHostProvider{domain.ProviderGitHub, domain.ProviderGitLab} {
+		linked, err := uc.integrationRepo.GetUserIDByLogin(ctx, provider, normalizeLogin(name))
+		if err == nil {
+			userID = linked
+			break
+		}
+		if !hasErrorCode(err, domain.ErrorCodeNotFound) {
+			return nil, err
+		}
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if hasErrorCode(err, domain.ErrorCodeNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []*domain.User{user}, nil
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+type codeOwnersRules []codeOwnersRule
+
+func parseCodeOwners(content string) (codeOwnersRules, error) {
+	var rules codeOwnersRules
+	for i, line := range strings.Split(content, "\n") {
+		if hash := strings.Index(line, "#"); hash >= 0 && (hash == 0 || line[hash-1] != '\\') {
+			line = line[:hash]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pattern, err := compileCodeOwnersPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		rules = append(rules, codeOwnersRule{pattern: pattern, owners: fields[1:]})
+	}
+	return rules, nil
+}
+
+func (rules codeOwnersRules) ownersOf(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(path) {
+			return rules[i].owners
+		}
+	}
+	return nil
+}
+
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.Contains(pattern, "[") {
+		return nil, fmt.Errorf("unsupported pattern syntax: %s", pattern)
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	lastSegment := pattern[strings.LastIndex(pattern, "/")+1:]
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*$")
+	case strings.ContainsAny(lastSegment, "*?"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOwnersLastMatchWins(t *testing.T) {
+	rules, err := parseCodeOwners(`
+# default owners
+*                   @global
+*.js                @js-owner
+/build/logs/        @logs
+docs/*              @docs
+apps/               @apps
+/scripts/ @scripts-a @scripts-b
+**/migrations       @dba
+internal/**/api     @api
+/apps/github        # no owners
+`)
+	require.NoError(t, err)
+
+	cases := map[string][]string{
+		"README.md":                      {"@global"},
+		"web/app.js":                     {"@js-owner"},
+		"build/logs/today.log":           {"@logs"},
+		"docs/getting-started.md":        {"@docs"},
+		"docs/build-app/troubleshoot.md": {"@global"},
+		"apps/web/main.go":               {"@apps"},
+		"src/apps/util.go":               {"@apps"},
+		"scripts/deploy.sh":              {"@scripts-a", "@scripts-b"},
+		"db/migrations/001.sql":          {"@dba"},
+		"migrations/002.sql":             {"@dba"},
+		"internal/api/handler.go":        {"@api"},
+		"internal/team/v1/api/x.go":      {"@api"},
+		"apps/github/client.go":          {},
+	}
+	for path, owners := range cases {
+		got := rules.ownersOf(path)
+		if len(owners) == 0 {
+			assert.Empty(t, got, path)
+			continue
+		}
+		assert.Equal(t, owners, got, path)
+	}
+}
+
+func TestCodeOwnersRejectsUnsupportedSyntax(t *testing.T) {
+	_, err := parseCodeOwners("!vendor/ @someone")
+	assert.Error(t, err)
+
+	_, err = parseCodeOwners("file[0-9].txt @someone")
+	assert.Error(t, err)
+}
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type directory struct {
+	users      map[string]*domain.User
+	tags       map[string][]string
+	policies   map[string]*domain.TeamPolicy
+	exclusions []*domain.ReviewerExclusion
+	teamUnits  map[string]string
+	unitTeams  map[string][]string
+	codeOwners string
+	prs        map[string]*domain.PullRequest
+	events     []*domain.Event
+	tenants    map[string]*directory
+}
+
+func newDirectory(users ...*domain.User) *directory {
+	dir := &directory{
+		users:     make(map[string]*domain.User),
+		tags:      make(map[string][]string),
+		policies:  make(map[string]*domain.TeamPolicy),
+		teamUnits: make(map[string]string),
+		unitTeams: make(map[string][]string),
+		prs:       make(map[string]*domain.PullRequest),
+	}
+	for _, user := range users {
+		dir.users[user.UserID] = user
+	}
+	return dir
+}
+
+func (d *directory) in(ctx context.Context) *directory {
+	if tenant, ok := d.tenants[domain.TenantFromContext(ctx)]; ok {
+		return tenant
+	}
+	return d
+}
+
+func (d *directory) removeMembership(userID, teamName string) {
+	user, ok := d.users[userID]
+	if !ok {
+		return
+	}
+	stored := *user
+	stored.Teams = nil
+	for _, name := range user.Teams {
+		if name != teamName {
+			stored.Teams = append(stored.Teams, name)
+		}
+	}
+	d.users[userID] = &stored
+}
+
+func (d *directory) setReviewTeam(teamName, newTeamName string) {
+	for prID, pr := range d.prs {
+		if pr.ReviewTeam == teamName {
+			stored := *pr
+			stored.ReviewTeam = newTeamName
+			d.prs[prID] = &stored
+		}
+	}
+}
+
+func member(userID, teamName string, seniority domain.Seniority) *domain.User {
+	return &domain.User{
+		UserID:    userID,
+		Username:  userID,
+		TeamName:  teamName,
+		IsActive:  true,
+		Seniority: seniority,
+		Role:      domain.TeamRoleMember,
+		Teams:     []string{teamName},
+	}
+}
+
+func lead(userID, teamName string, seniority domain.Seniority) *domain.User {
+	user := member(userID, teamName, seniority)
+	user.Role = domain.TeamRoleLead
+	return user
+}
+
+type directoryUserRepo struct {
+	tenantUserRepo
+	dir *directory
+}
+
+func (r directoryUserRepo) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	r.seen(ctx, "users.GetByID")
+	user, ok := r.dir.in(ctx).users[userID]
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+	}
+	stored := *user
+	stored.Teams = append([]string{}, user.Teams...)
+	return &stored, nil
+}
+
+func (r directoryUserRepo) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	r.seen(ctx, "users.CreateOrUpdate")
+	dir := r.dir.in(ctx)
+	stored := *user
+	if existing, ok := dir.users[user.UserID]; ok {
+		stored.Teams = existing.Teams
+	}
+	if user.TeamName != "" && !containsString(stored.Teams, user.TeamName) {
+		stored.Teams = append(append([]string{}, stored.Teams...), user.TeamName)
+	}
+	dir.users[user.UserID] = &stored
+	return nil
+}
+
+func (r directoryUserRepo) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	r.seen(ctx, "users.GetByIDs")
+	var users []*domain.User
+	for _, userID := range userIDs {
+		if user, ok := r.dir.in(ctx).users[userID]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r directoryUserRepo) GetByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
+	r.seen(ctx, "users.GetByTeamName")
+	var users []*domain.User
+	for _, user := range r.dir.in(ctx).users {
+		if user.ArchivedAt == nil && containsString(user.Teams, teamName) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+	return users, nil
+}
+
+func (r directoryUserRepo) RemoveMembership(ctx context.Context, userID, teamName string) error {
+	r.seen(ctx, "users.RemoveMembership")
+	r.dir.in(ctx).removeMembership(userID, teamName)
+	return nil
+}
+
+func (r directoryUserRepo) Archive(ctx context.Context, userID string) error {
+	r.seen(ctx, "users.Archive")
+	dir := r.dir.in(ctx)
+	user, ok := dir.users[userID]
+	if !ok || user.ArchivedAt != nil {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "user not found or already archived")
+	}
+	now := time.Now()
+	stored := *user
+	stored.ArchivedAt = &now
+	stored.IsActive = false
+	dir.users[userID] = &stored
+	return nil
+}
+
+func (r directoryUserRepo) GetTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	r.seen(ctx, "users.GetTags")
+	tags := make(map[string][]string)
+	for _, userID := range userIDs {
+		if userTags, ok := r.dir.in(ctx).tags[userID]; ok {
+			tags[userID] = userTags
+		}
+	}
+	return tags, nil
+}
+
+type directoryTeamRepo struct {
+	tenantTeamRepo
+	dir *directory
+}
+
+func (r directoryTeamRepo) Exists(ctx context.Context, teamName string) (bool, error) {
+	r.seen(ctx, "teams.Exists")
+	for _, user := range r.dir.in(ctx).users {
+		if user.ArchivedAt == nil && containsString(user.Teams, teamName) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r directoryTeamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	r.seen(ctx, "teams.GetByName")
+	team := &domain.Team{TeamName: teamName}
+	for _, user := range r.dir.in(ctx).users {
+		if user.ArchivedAt != nil || !containsString(user.Teams, teamName) {
+			continue
+		}
+		role := domain.TeamRoleMember
+		if user.TeamName == teamName {
+			role = user.Role
+		}
+		team.Members = append(team.Members, domain.TeamMember{
+			UserID:    user.UserID,
+			Username:  user.Username,
+			IsActive:  user.IsActive,
+			Seniority: user.Seniority,
+			Role:      role,
+			IsPrimary: user.TeamName == teamName,
+		})
+	}
+	if len(team.Members) == 0 {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	sort.Slice(team.Members, func(i, j int) bool { return team.Members[i].UserID < team.Members[j].UserID })
+	return team, nil
+}
+
+func (r directoryTeamRepo) Rename(ctx context.Context, teamName, newTeamName string) error {
+	r.seen(ctx, "teams.Rename")
+	dir := r.dir.in(ctx)
+	for userID, user := range dir.users {
+		stored := *user
+		stored.Teams = nil
+		for _, name := range user.Teams {
+			if name == teamName {
+				name = newTeamName
+			}
+			stored.Teams = append(stored.Teams, name)
+		}
+		if stored.TeamName == teamName {
+			stored.TeamName = newTeamName
+		}
+		dir.users[userID] = &stored
+	}
+	if policy, ok := dir.policies[teamName]; ok {
+		delete(dir.policies, teamName)
+		renamed := *policy
+		renamed.TeamName = newTeamName
+		dir.policies[newTeamName] = &renamed
+	}
+	if unitID, ok := dir.teamUnits[teamName]; ok {
+		delete(dir.teamUnits, teamName)
+		dir.teamUnits[newTeamName] = unitID
+	}
+	dir.setReviewTeam(teamName, newTeamName)
+	return nil
+}
+
+func (r directoryTeamRepo) DeleteSettings(ctx context.Context, teamName string) error {
+	r.seen(ctx, "teams.DeleteSettings")
+	dir := r.dir.in(ctx)
+	delete(dir.policies, teamName)
+	delete(dir.teamUnits, teamName)
+	for userID := range dir.users {
+		dir.removeMembership(userID, teamName)
+	}
+	dir.setReviewTeam(teamName, "")
+	return nil
+}
+
+func (r directoryTeamRepo) GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	r.seen(ctx, "teams.GetPolicy")
+	if policy, ok := r.dir.in(ctx).policies[teamName]; ok {
+		return policy, nil
+	}
+	return &domain.TeamPolicy{TeamName: teamName}, nil
+}
+
+type directoryExclusionRepo struct {
+	tenantExclusionRepo
+	dir *directory
+}
+
+func (r directoryExclusionRepo) GetExcludedReviewers(ctx context.Context, authorIDs []string) ([]string, error) {
+	r.seen(ctx, "exclusions.GetExcludedReviewers")
+	var userIDs []string
+	for _, exclusion := range r.dir.in(ctx).exclusions {
+		if containsString(authorIDs, exclusion.UserID) {
+			userIDs = append(userIDs, exclusion.ExcludedUserID)
+		}
+		if exclusion.Symmetric && containsString(authorIDs, exclusion.ExcludedUserID) {
+			userIDs = append(userIDs, exclusion.UserID)
+		}
+	}
+	return userIDs, nil
+}
+
+type directoryOrgUnitRepo struct {
+	tenantOrgUnitRepo
+	dir *directory
+}
+
+func (r directoryOrgUnitRepo) GetUnitIDByTeam(ctx context.Context, teamName string) (string, error) {
+	r.seen(ctx, "orgUnits.GetUnitIDByTeam")
+	unitID, ok := r.dir.in(ctx).teamUnits[teamName]
+	if !ok {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, "team is not assigned to an org unit")
+	}
+	return unitID, nil
+}
+
+func (r directoryOrgUnitRepo) GetSubtreeTeams(ctx context.Context, unitID string) ([]string, error) {
+	r.seen(ctx, "orgUnits.GetSubtreeTeams")
+	return r.dir.in(ctx).unitTeams[unitID], nil
+}
+
+type directoryPullRequestRepo struct {
+	tenantPullRequestRepo
+	dir *directory
+}
+
+func (r directoryPullRequestRepo) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	r.seen(ctx, "pullRequests.GetByID")
+	pr, ok := r.dir.in(ctx).prs[prID]
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "PR not found")
+	}
+	stored := *pr
+	stored.AssignedReviewers = append([]string{}, pr.AssignedReviewers...)
+	return &stored, nil
+}
+
+func (r directoryPullRequestRepo) GetByReviewerID(ctx context.Context, reviewerID string) ([]*domain.PullRequest, error) {
+	r.seen(ctx, "pullRequests.GetByReviewerID")
+	var prs []*domain.PullRequest
+	for prID, pr := range r.dir.in(ctx).prs {
+		if containsString(pr.AssignedReviewers, reviewerID) {
+			stored, err := r.GetByID(ctx, prID)
+			if err != nil {
+				return nil, err
+			}
+			prs = append(prs, stored)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].PullRequestID < prs[j].PullRequestID })
+	return prs, nil
+}
+
+func (r directoryPullRequestRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
+	r.seen(ctx, "pullRequests.Update")
+	r.dir.in(ctx).prs[pr.PullRequestID] = pr
+	return nil
+}
+
+type directoryOutboxRepo struct {
+	tenantOutboxRepo
+	dir *directory
+}
+
+func (r directoryOutboxRepo) Append(ctx context.Context, events ...*domain.Event) error {
+	r.seen(ctx, "outbox.Append")
+	r.dir.in(ctx).events = append(r.dir.in(ctx).events, events...)
+	return nil
+}
+
+type directoryCodeOwnersRepo struct {
+	tenantCodeOwnersRepo
+	dir *directory
+}
+
+func (r directoryCodeOwnersRepo) Get(ctx context.Context, scope domain.CodeOwnersScope, scopeName string) (*domain.CodeOwnersRuleset, error) {
+	r.seen(ctx, "codeowners.Get")
+	dir := r.dir.in(ctx)
+	if dir.codeOwners == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "codeowners not found")
+	}
+	return &domain.CodeOwnersRuleset{Scope: scope, ScopeName: scopeName, Content: dir.codeOwners}, nil
+}
+
+func newDirectoryUseCase(dir *directory) *PullRequestUseCase {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+	userRepo := directoryUserRepo{tenantUserRepo{rec}, dir}
+	prUseCase.codeOwners = NewCodeOwnersUseCase(directoryCodeOwnersRepo{tenantCodeOwnersRepo{rec}, dir}, userRepo, tenantIntegrationRepo{rec})
+	prUseCase.userRepo = userRepo
+	prUseCase.prRepo = directoryPullRequestRepo{tenantPullRequestRepo{rec}, dir}
+	prUseCase.teamRepo = directoryTeamRepo{tenantTeamRepo{rec}, dir}
+	prUseCase.exclusionRepo = directoryExclusionRepo{tenantExclusionRepo{rec}, dir}
+	prUseCase.orgUnitRepo = directoryOrgUnitRepo{tenantOrgUnitRepo{rec}, dir}
+	prUseCase.events.outboxRepo = directoryOutboxRepo{tenantOutboxRepo{rec}, dir}
+	return prUseCase
+}
+
+func newDirectoryUserUseCase(dir *directory) *UserUseCase {
+	prUseCase := newDirectoryUseCase(dir)
+	userUC := newTenantUseCases(&tenantRecorder{})[2].(*UserUseCase)
+	userUC.userRepo = prUseCase.userRepo
+	userUC.teamRepo = prUseCase.teamRepo
+	userUC.reviews = prUseCase
+	userUC.events = prUseCase.events
+	return userUC
+}
+
+func newDirectoryTeamUseCase(dir *directory) *TeamUseCase {
+	prUseCase := newDirectoryUseCase(dir)
+	teamUC := newTenantUseCases(&tenantRecorder{})[3].(*TeamUseCase)
+	teamUC.userRepo = prUseCase.userRepo
+	teamUC.teamRepo = prUseCase.teamRepo
+	teamUC.reviews = prUseCase
+	return teamUC
+}
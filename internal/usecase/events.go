@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"avitotest/internal/domain"
+)
+
+type eventRecorder struct {
+	outboxRepo domain.OutboxRepository
+	transactor domain.Transactor
+	publisher  domain.EventPublisher
+}
+
+func newEventRecorder(outboxRepo domain.OutboxRepository, transactor domain.Transactor, publisher domain.EventPublisher) *eventRecorder {
+	return &eventRecorder{
+		outboxRepo: outboxRepo,
+		transactor: transactor,
+		publisher:  publisher,
+	}
+}
+
+func (r *eventRecorder) record(ctx context.Context, eventType domain.EventType, aggregateID string, payload interface{}) error {
+	event, err := domain.NewEvent(eventType, aggregateID, payload)
+	if err != nil {
+		return err
+	}
+	event.TenantID = domain.TenantFromContext(ctx)
+	if err := r.outboxRepo.Append(ctx, event); err != nil {
+		return err
+	}
+
+	publishCtx := context.WithoutCancel(ctx)
+	r.transactor.AfterCommit(ctx, func() {
+		r.publisher.Publish(publishCtx, event)
+	})
+	return nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"avitotest/internal/domain"
+)
+
+type ExclusionUseCase struct {
+	exclusionRepo domain.ExclusionRepository
+	userRepo      domain.UserRepository
+}
+
+func NewExclusionUseCase(exclusionRepo domain.ExclusionRepository, userRepo domain.UserRepository) *ExclusionUseCase {
+	return &ExclusionUseCase{
+		exclusionRepo: exclusionRepo,
+		userRepo:      userRepo,
+	}
+}
+
+func (uc *ExclusionUseCase) AddExclusion(ctx context.Context, exclusion *domain.ReviewerExclusion) (*domain.ReviewerExclusion, error) {
+	if exclusion.UserID == "" || exclusion.ExcludedUserID == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "user_id and excluded_user_id are required")
+	}
+	if exclusion.UserID == exclusion.ExcludedUserID {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "user cannot be excluded from reviewing themselves")
+	}
+	for _, userID := range []string{exclusion.UserID, exclusion.ExcludedUserID} {
+		if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.exclusionRepo.Upsert(ctx, exclusion); err != nil {
+		return nil, err
+	}
+	return exclusion, nil
+}
+
+func (uc *ExclusionUseCase) DeleteExclusion(ctx context.Context, exclusionID int64) error {
+	return uc.exclusionRepo.Delete(ctx, exclusionID)
+}
+
+func (uc *ExclusionUseCase) ListExclusions(ctx context.Context, userID string) ([]*domain.ReviewerExclusion, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.exclusionRepo.ListByUserID(ctx, userID)
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exclusionDirectory() *directory {
+	dir := newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("x", "backend", domain.SeniorityMiddle),
+		member("y", "backend", domain.SeniorityMiddle),
+		member("z", "backend", domain.SeniorityMiddle),
+	)
+	dir.exclusions = []*domain.ReviewerExclusion{
+		{UserID: "author", ExcludedUserID: "x"},
+		{UserID: "y", ExcludedUserID: "author", Symmetric: true},
+		{UserID: "z", ExcludedUserID: "author"},
+	}
+	return dir
+}
+
+func TestPlanAssignmentAppliesOneWayAndSymmetricExclusions(t *testing.T) {
+	uc := newDirectoryUseCase(exclusionDirectory())
+
+	for seed := int64(1); seed <= 20; seed++ {
+		plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"z"}, plan.reviewers, "a one-way exclusion set by z only stops author reviewing z")
+		assert.ElementsMatch(t, []string{"x", "y"}, plan.explanation.RemovedBy("exclusion"))
+		assert.Equal(t, 4, plan.explanation.PoolSize)
+		assert.Equal(t, 1, plan.explanation.EligibleCount)
+	}
+}
+
+func TestPlanAssignmentWithoutExclusionsReportsNothing(t *testing.T) {
+	dir := exclusionDirectory()
+	dir.exclusions = nil
+	uc := newDirectoryUseCase(dir)
+
+	plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	assert.Len(t, plan.reviewers, 2)
+	assert.Nil(t, plan.explanation.RemovedBy("exclusion"))
+	assert.Equal(t, 3, plan.explanation.EligibleCount)
+}
+
+func TestCreatePullRequestReportsExcludedCandidates(t *testing.T) {
+	uc := newDirectoryUseCase(exclusionDirectory())
+
+	pr, err := uc.CreatePullRequest(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", PullRequestName: "pr1", AuthorID: "author"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"z"}, pr.AssignedReviewers)
+	assert.ElementsMatch(t, []string{"x", "y"}, pr.Assignment.RemovedBy("exclusion"))
+}
+
+func TestReassignReviewerSkipsExcludedCandidates(t *testing.T) {
+	dir := exclusionDirectory()
+	dir.users["w"] = member("w", "backend", domain.SeniorityMiddle)
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"z"}}
+	uc := newDirectoryUseCase(dir)
+
+	pr, newReviewerID, err := uc.ReassignReviewer(context.Background(), "pr1", "z")
+	require.NoError(t, err)
+	assert.Equal(t, "w", newReviewerID)
+	assert.Equal(t, []string{"w"}, pr.AssignedReviewers)
+	assert.ElementsMatch(t, []string{"x", "y"}, pr.Assignment.RemovedBy("exclusion"))
+}
+
+func TestReassignReviewerFailsWhenExclusionsEmptyThePool(t *testing.T) {
+	dir := exclusionDirectory()
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"z"}}
+	uc := newDirectoryUseCase(dir)
+
+	_, _, err := uc.ReassignReviewer(context.Background(), "pr1", "z")
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNoCandidate, domainErr.Code)
+	assert.Equal(t, []string{"z"}, dir.prs["pr1"].AssignedReviewers)
+}
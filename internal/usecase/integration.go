@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"avitotest/internal/domain"
+)
+
+type IntegrationUseCase struct {
+	integrationRepo domain.IntegrationRepository
+	userRepo        domain.UserRepository
+	prRepo          domain.PullRequestRepository
+	prUseCase       *PullRequestUseCase
+	transactor      domain.Transactor
+	directories     map[domain.CodeHostProvider]domain.CodeHostUserDirectory
+}
+
+func NewIntegrationUseCase(
+	integrationRepo domain.IntegrationRepository,
+	userRepo domain.UserRepository,
+	prRepo domain.PullRequestRepository,
+	prUseCase *PullRequestUseCase,
+	transactor domain.Transactor,
+	directories map[domain.CodeHostProvider]domain.CodeHostUserDirectory,
+) *IntegrationUseCase {
+	return &IntegrationUseCase{
+		integrationRepo: integrationRepo,
+		userRepo:        userRepo,
+		prRepo:          prRepo,
+		prUseCase:       prUseCase,
+		transactor:      transactor,
+		directories:     directories,
+	}
+}
+
+func (uc *IntegrationUseCase) LinkIdentity(ctx context.Context, identity *domain.ExternalIdentity) (*domain.ExternalIdentity, error) {
+	if !identity.Provider.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "unknown provider: "+string(identity.Provider))
+	}
+	identity.ExternalLogin = normalizeLogin(identity.ExternalLogin)
+	if identity.ExternalLogin == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "external_login is required")
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, identity.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.integrationRepo.LinkIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (uc *IntegrationUseCase) UnlinkIdentity(ctx context.Context, provider domain.CodeHostProvider, externalLogin string) error {
+	return uc.integrationRepo.UnlinkIdentity(ctx, provider, normalizeLogin(externalLogin))
+}
+
+func (uc *IntegrationUseCase) ListIdentities(ctx context.Context, userID string) ([]*domain.ExternalIdentity, error) {
+	identities, err := uc.integrationRepo.ListIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if identities == nil {
+		identities = []*domain.ExternalIdentity{}
+	}
+	return identities, nil
+}
+
+func (uc *IntegrationUseCase) HandlePullRequestEvent(ctx context.Context, event *domain.CodeHostPullRequestEvent) (*domain.PullRequest, error) {
+	if event.Action == domain.CodeHostActionOpened {
+		return uc.openPullRequest(ctx, event)
+	}
+
+	link, err := uc.integrationRepo.GetPullRequestLink(ctx, event.Provider, event.Repository, event.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	switch event.Action {
+	case domain.CodeHostActionMerged:
+		return uc.prUseCase.MergePullRequest(ctx, link.PullRequestID)
+	case domain.CodeHostActionClosed:
+		return uc.prUseCase.ClosePullRequest(ctx, link.PullRequestID)
+	case domain.CodeHostActionReopened:
+		return uc.prUseCase.ReopenPullRequest(ctx, link.PullRequestID)
+	}
+	return nil, domain.NewDomainError(domain.ErrorCodeValidation, "unsupported action: "+string(event.Action))
+}
+
+func (uc *IntegrationUseCase) openPullRequest(ctx context.Context, event *domain.CodeHostPullRequestEvent) (*domain.PullRequest, error) {
+	link, err := uc.integrationRepo.GetPullRequestLink(ctx, event.Provider, event.Repository, event.Number)
+	if err == nil {
+		return uc.prRepo.GetByID(ctx, link.PullRequestID)
+	}
+	if !hasErrorCode(err, domain.ErrorCodeNotFound) {
+		return nil, err
+	}
+
+	authorLogin, err := uc.authorLogin(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	authorID, err := uc.integrationRepo.GetUserIDByLogin(ctx, event.Provider, authorLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	prID := fmt.Sprintf("%s/%s#%d", event.Provider, event.Repository, event.Number)
+
+	var pr *domain.PullRequest
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = uc.prUseCase.CreatePullRequest(ctx, CreatePullRequestInput{
+			PullRequestID:   prID,
+			PullRequestName: event.Title,
+			AuthorID:        authorID,
+			Repository:      event.Repository,
+		})
+		if err != nil {
+			return err
+		}
+		return uc.integrationRepo.LinkPullRequest(ctx, &domain.ExternalPullRequest{
+			PullRequestID: prID,
+			Provider:      event.Provider,
+			Repository:    event.Repository,
+			Number:        event.Number,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (uc *IntegrationUseCase) authorLogin(ctx context.Context, event *domain.CodeHostPullRequestEvent) (string, error) {
+	if login := normalizeLogin(event.AuthorLogin); login != "" {
+		return login, nil
+	}
+
+	directory, ok := uc.directories[event.Provider]
+	if !ok || event.AuthorExternalID == 0 {
+		return "", domain.NewDomainError(domain.ErrorCodeValidation, "pull request author is unknown")
+	}
+	login, err := directory.GetLogin(ctx, event.AuthorExternalID)
+	if err != nil {
+		return "", err
+	}
+	return normalizeLogin(login), nil
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+func hasErrorCode(err error, code domain.ErrorCode) bool {
+	var domainErr *domain.DomainError
+	return errors.As(err, &domainErr) && domainErr.Code == code
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unlinkedIntegrationRepo struct {
+	tenantIntegrationRepo
+	logins []string
+}
+
+func (r *unlinkedIntegrationRepo) GetPullRequestLink(ctx context.Context, provider domain.CodeHostProvider, repository string, number int) (*domain.ExternalPullRequest, error) {
+	return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pull request link not found")
+}
+
+func (r *unlinkedIntegrationRepo) GetUserIDByLogin(ctx context.Context, provider domain.CodeHostProvider, externalLogin string) (string, error) {
+	r.logins = append(r.logins, externalLogin)
+	return "u1", nil
+}
+
+type staticDirectory map[int64]string
+
+func (d staticDirectory) GetLogin(ctx context.Context, externalID int64) (string, error) {
+	login, ok := d[externalID]
+	if !ok {
+		return "", domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return login, nil
+}
+
+func newIntegrationUseCase(rec *tenantRecorder, integrationRepo domain.IntegrationRepository, directories map[domain.CodeHostProvider]domain.CodeHostUserDirectory) *IntegrationUseCase {
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+	return NewIntegrationUseCase(integrationRepo, tenantUserRepo{rec}, tenantPullRequestRepo{rec}, prUseCase, tenantTransactor{rec}, directories)
+}
+
+func TestOpenPullRequestResolvesAuthorThroughDirectory(t *testing.T) {
+	rec := &tenantRecorder{}
+	integrationRepo := &unlinkedIntegrationRepo{tenantIntegrationRepo: tenantIntegrationRepo{rec}}
+	uc := newIntegrationUseCase(rec, integrationRepo, map[domain.CodeHostProvider]domain.CodeHostUserDirectory{
+		domain.ProviderGitLab: staticDirectory{42: "Alice"},
+	})
+
+	pr, err := uc.HandlePullRequestEvent(context.Background(), &domain.CodeHostPullRequestEvent{
+		Provider:         domain.ProviderGitLab,
+		Action:           domain.CodeHostActionOpened,
+		Repository:       "acme/api",
+		Number:           3,
+		AuthorExternalID: 42,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "gitlab/acme/api#3", pr.PullRequestID)
+	assert.Equal(t, []string{"alice"}, integrationRepo.logins)
+}
+
+func TestOpenPullRequestPrefersLoginFromPayload(t *testing.T) {
+	rec := &tenantRecorder{}
+	integrationRepo := &unlinkedIntegrationRepo{tenantIntegrationRepo: tenantIntegrationRepo{rec}}
+	uc := newIntegrationUseCase(rec, integrationRepo, map[domain.CodeHostProvider]domain.CodeHostUserDirectory{
+		domain.ProviderGitLab: staticDirectory{},
+	})
+
+	_, err := uc.HandlePullRequestEvent(context.Background(), &domain.CodeHostPullRequestEvent{
+		Provider:         domain.ProviderGitLab,
+		Action:           domain.CodeHostActionOpened,
+		Repository:       "acme/api",
+		Number:           3,
+		AuthorLogin:      " Bob ",
+		AuthorExternalID: 42,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, integrationRepo.logins)
+}
+
+func TestOpenPullRequestFailsWithoutKnownAuthor(t *testing.T) {
+	rec := &tenantRecorder{}
+	integrationRepo := &unlinkedIntegrationRepo{tenantIntegrationRepo: tenantIntegrationRepo{rec}}
+	uc := newIntegrationUseCase(rec, integrationRepo, nil)
+
+	_, err := uc.HandlePullRequestEvent(context.Background(), &domain.CodeHostPullRequestEvent{
+		Provider:         domain.ProviderGitLab,
+		Action:           domain.CodeHostActionOpened,
+		Repository:       "acme/api",
+		Number:           3,
+		AuthorExternalID: 42,
+	})
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeValidation, domainErr.Code)
+	assert.Empty(t, integrationRepo.logins)
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+
+	"avitotest/internal/domain"
+)
+
+type OrgUnitUseCase struct {
+	orgUnitRepo domain.OrgUnitRepository
+	teamRepo    domain.TeamRepository
+	statsRepo   domain.StatsRepository
+	transactor  domain.Transactor
+}
+
+func NewOrgUnitUseCase(
+	orgUnitRepo domain.OrgUnitRepository,
+	teamRepo domain.TeamRepository,
+	statsRepo domain.StatsRepository,
+	transactor domain.Transactor,
+) *OrgUnitUseCase {
+	return &OrgUnitUseCase{
+		orgUnitRepo: orgUnitRepo,
+		teamRepo:    teamRepo,
+		statsRepo:   statsRepo,
+		transactor:  transactor,
+	}
+}
+
+func (uc *OrgUnitUseCase) CreateUnit(ctx context.Context, unit *domain.OrgUnit) (*domain.OrgUnit, error) {
+	if unit.UnitID == "" || unit.Name == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "unit_id and name are required")
+	}
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if unit.ParentID != nil {
+			if _, err := uc.orgUnitRepo.Get(ctx, *unit.ParentID); err != nil {
+				return err
+			}
+		}
+		return uc.orgUnitRepo.Create(ctx, unit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.orgUnitRepo.Get(ctx, unit.UnitID)
+}
+
+func (uc *OrgUnitUseCase) GetUnit(ctx context.Context, unitID string) (*domain.OrgUnit, error) {
+	return uc.orgUnitRepo.Get(ctx, unitID)
+}
+
+func (uc *OrgUnitUseCase) ListUnits(ctx context.Context) ([]*domain.OrgUnit, error) {
+	return uc.orgUnitRepo.List(ctx)
+}
+
+func (uc *OrgUnitUseCase) UpdateUnit(ctx context.Context, unitID, name string, parentID *string) (*domain.OrgUnit, error) {
+	if name == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "name is required")
+	}
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.orgUnitRepo.Get(ctx, unitID); err != nil {
+			return err
+		}
+		if parentID != nil {
+			if *parentID == unitID {
+				return domain.NewDomainError(domain.ErrorCodeValidation, "org unit cannot be its own parent")
+			}
+			if _, err := uc.orgUnitRepo.Get(ctx, *parentID); err != nil {
+				return err
+			}
+			ancestors, err := uc.orgUnitRepo.GetAncestorIDs(ctx, *parentID)
+			if err != nil {
+				return err
+			}
+			if containsString(ancestors, unitID) {
+				return domain.NewDomainError(domain.ErrorCodeValidation, "org unit cannot be moved under its own descendant")
+			}
+		}
+		return uc.orgUnitRepo.Update(ctx, &domain.OrgUnit{UnitID: unitID, Name: name, ParentID: parentID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.orgUnitRepo.Get(ctx, unitID)
+}
+
+func (uc *OrgUnitUseCase) DeleteUnit(ctx context.Context, unitID string) error {
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		unit, err := uc.orgUnitRepo.Get(ctx, unitID)
+		if err != nil {
+			return err
+		}
+		if len(unit.Children) > 0 || len(unit.Teams) > 0 {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "org unit still has child units or teams")
+		}
+		return uc.orgUnitRepo.Delete(ctx, unitID)
+	})
+}
+
+func (uc *OrgUnitUseCase) SetTeamUnit(ctx context.Context, teamName string, unitID *string) error {
+	exists, err := uc.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	if unitID != nil {
+		if _, err := uc.orgUnitRepo.Get(ctx, *unitID); err != nil {
+			return err
+		}
+	}
+	return uc.orgUnitRepo.SetTeamUnit(ctx, teamName, unitID)
+}
+
+func (uc *OrgUnitUseCase) GetStats(ctx context.Context, unitID string) (*domain.OrgUnitStats, error) {
+	if _, err := uc.orgUnitRepo.Get(ctx, unitID); err != nil {
+		return nil, err
+	}
+
+	teamNames, err := uc.orgUnitRepo.GetSubtreeTeams(ctx, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := uc.statsRepo.GetTeamStats(ctx, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.OrgUnitStats{UnitID: unitID, Teams: teams}
+	for _, team := range teams {
+		stats.Totals.Members += team.Members
+		stats.Totals.ActiveMembers += team.ActiveMembers
+		stats.Totals.OpenPRs += team.OpenPRs
+		stats.Totals.MergedPRs += team.MergedPRs
+		stats.Totals.ClosedPRs += team.ClosedPRs
+		stats.Totals.OpenReviews += team.OpenReviews
+		stats.Totals.SLABreaches += team.SLABreaches
+	}
+	return stats, nil
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func departmentDirectory() *directory {
+	dir := newDirectory(
+		member("author", "backend", domain.SeniorityMiddle),
+		member("b1", "backend", domain.SeniorityMiddle),
+		member("p1", "platform", domain.SeniorityMiddle),
+		member("p2", "platform", domain.SenioritySenior),
+		member("x1", "sales", domain.SenioritySenior),
+	)
+	dir.teamUnits["backend"] = "engineering"
+	dir.teamUnits["platform"] = "engineering"
+	dir.unitTeams["engineering"] = []string{"backend", "platform"}
+	return dir
+}
+
+func reasonsByUser(explanation *domain.AssignmentExplanation) map[string]domain.AssignmentReason {
+	reasons := make(map[string]domain.AssignmentReason)
+	for _, choice := range explanation.Reviewers {
+		reasons[choice.UserID] = choice.Reason
+	}
+	return reasons
+}
+
+func TestPlanAssignmentFillsFromDepartment(t *testing.T) {
+	uc := newDirectoryUseCase(departmentDirectory())
+
+	for seed := int64(1); seed <= 20; seed++ {
+		plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+
+		require.Len(t, plan.reviewers, 2)
+		assert.Equal(t, "b1", plan.reviewers[0])
+		assert.Contains(t, []string{"p1", "p2"}, plan.reviewers[1])
+		assert.Equal(t, 2, plan.explanation.FallbackPoolSize)
+		assert.Equal(t, domain.AssignmentReasonTeam, reasonsByUser(plan.explanation)["b1"])
+		assert.Equal(t, domain.AssignmentReasonDepartment, reasonsByUser(plan.explanation)[plan.reviewers[1]])
+	}
+}
+
+func TestPlanAssignmentTakesMissingSeniorFromDepartment(t *testing.T) {
+	dir := departmentDirectory()
+	dir.users["b2"] = member("b2", "backend", domain.SeniorityMiddle)
+	dir.policies["backend"] = &domain.TeamPolicy{TeamName: "backend", MinSeniorReviewers: 1}
+	uc := newDirectoryUseCase(dir)
+
+	for seed := int64(1); seed <= 20; seed++ {
+		plan, err := uc.planAssignment(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}, rand.New(rand.NewSource(seed)))
+		require.NoError(t, err)
+
+		assert.Contains(t, plan.reviewers, "p2", "the only senior in reach sits in the department")
+		assert.Len(t, plan.reviewers, 2)
+		assert.Equal(t, domain.AssignmentReasonDepartment, reasonsByUser(plan.explanation)["p2"])
+	}
+}
+
+func TestPlanAssignmentFailsWhenDepartmentHasNoSenior(t *testing.T) {
+	dir := departmentDirectory()
+	dir.policies["backend"] = &domain.TeamPolicy{TeamName: "backend", MinSeniorReviewers: 1}
+	dir.users["p2"].Seniority = domain.SeniorityMiddle
+	uc := newDirectoryUseCase(dir)
+
+	_, err := uc.planAssignment(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}, rand.New(rand.NewSource(1)))
+	requireErrorCode(t, err, domain.ErrorCodeNoCandidate)
+
+	delete(dir.teamUnits, "backend")
+	dir.users["p2"].Seniority = domain.SenioritySenior
+	_, err = uc.planAssignment(context.Background(), CreatePullRequestInput{PullRequestID: "pr1", AuthorID: "author"}, rand.New(rand.NewSource(1)))
+	requireErrorCode(t, err, domain.ErrorCodeNoCandidate)
+}
+
+func TestReassignReviewerFallsBackToDepartment(t *testing.T) {
+	dir := departmentDirectory()
+	delete(dir.users, "p1")
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"b1"}}
+	uc := newDirectoryUseCase(dir)
+
+	pr, newReviewerID, err := uc.ReassignReviewer(context.Background(), "pr1", "b1")
+	require.NoError(t, err)
+	assert.Equal(t, "p2", newReviewerID)
+	assert.Equal(t, []string{"p2"}, pr.AssignedReviewers)
+	assert.Equal(t, 1, pr.Assignment.FallbackPoolSize)
+}
+
+func TestReassignReviewerTakesSeniorFromDepartment(t *testing.T) {
+	dir := departmentDirectory()
+	dir.users["b0"] = member("b0", "backend", domain.SenioritySenior)
+	dir.users["b2"] = member("b2", "backend", domain.SeniorityMiddle)
+	dir.policies["backend"] = &domain.TeamPolicy{TeamName: "backend", MinSeniorReviewers: 1}
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"b0", "b1"}}
+	uc := newDirectoryUseCase(dir)
+
+	pr, newReviewerID, err := uc.ReassignReviewer(context.Background(), "pr1", "b0")
+	require.NoError(t, err)
+	assert.Equal(t, "p2", newReviewerID, "b2 is free but cannot replace the only senior")
+	assert.Equal(t, []string{"p2", "b1"}, pr.AssignedReviewers)
+	assert.ElementsMatch(t, []string{"p1", "b2"}, pr.Assignment.RemovedBy("team_policy"))
+
+	delete(dir.teamUnits, "backend")
+	dir.users["b0"].IsActive = false
+	_, _, err = uc.ReassignReviewer(context.Background(), "pr1", "b1")
+	require.NoError(t, err, "a middle reviewer can still be replaced from the team")
+	_, _, err = uc.ReassignReviewer(context.Background(), "pr1", "p2")
+	requireErrorCode(t, err, domain.ErrorCodeNoCandidate)
+}
+
+type orgUnitTree struct {
+	tenantOrgUnitRepo
+	units   map[string]*domain.OrgUnit
+	updated []*domain.OrgUnit
+}
+
+func newOrgUnitTree(units ...*domain.OrgUnit) *orgUnitTree {
+	tree := &orgUnitTree{tenantOrgUnitRepo: tenantOrgUnitRepo{&tenantRecorder{}}, units: make(map[string]*domain.OrgUnit)}
+	for _, unit := range units {
+		tree.units[unit.UnitID] = unit
+	}
+	return tree
+}
+
+func orgUnit(unitID, parentID string, teams ...string) *domain.OrgUnit {
+	unit := &domain.OrgUnit{UnitID: unitID, Name: unitID, Teams: teams}
+	if parentID != "" {
+		unit.ParentID = &parentID
+	}
+	return unit
+}
+
+func (r *orgUnitTree) Get(ctx context.Context, unitID string) (*domain.OrgUnit, error) {
+	unit, ok := r.units[unitID]
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "org unit not found")
+	}
+	return unit, nil
+}
+
+func (r *orgUnitTree) Update(ctx context.Context, unit *domain.OrgUnit) error {
+	r.updated = append(r.updated, unit)
+	return nil
+}
+
+func (r *orgUnitTree) GetAncestorIDs(ctx context.Context, unitID string) ([]string, error) {
+	var ancestors []string
+	for unit := r.units[unitID]; unit != nil; {
+		ancestors = append(ancestors, unit.UnitID)
+		if unit.ParentID == nil {
+			break
+		}
+		unit = r.units[*unit.ParentID]
+	}
+	return ancestors, nil
+}
+
+func (r *orgUnitTree) GetSubtreeTeams(ctx context.Context, unitID string) ([]string, error) {
+	teams := append([]string{}, r.units[unitID].Teams...)
+	for _, unit := range r.units {
+		if unit.ParentID != nil && *unit.ParentID == unitID {
+			children, err := r.GetSubtreeTeams(ctx, unit.UnitID)
+			if err != nil {
+				return nil, err
+			}
+			teams = append(teams, children...)
+		}
+	}
+	return teams, nil
+}
+
+type orgUnitStatsRepo struct {
+	stats     map[string]*domain.TeamStats
+	requested []string
+}
+
+func (r *orgUnitStatsRepo) GetTeamStats(ctx context.Context, teamNames []string) ([]*domain.TeamStats, error) {
+	r.requested = teamNames
+	var stats []*domain.TeamStats
+	for _, name := range teamNames {
+		stats = append(stats, r.stats[name])
+	}
+	return stats, nil
+}
+
+func newOrgUnitUseCase(tree *orgUnitTree, stats *orgUnitStatsRepo) *OrgUnitUseCase {
+	uc := newTenantUseCases(&tenantRecorder{})[6].(*OrgUnitUseCase)
+	uc.orgUnitRepo = tree
+	if stats != nil {
+		uc.statsRepo = stats
+	}
+	return uc
+}
+
+func TestUpdateUnitRejectsCycles(t *testing.T) {
+	tree := newOrgUnitTree(
+		orgUnit("company", ""),
+		orgUnit("engineering", "company"),
+		orgUnit("backend", "engineering"),
+		orgUnit("sales", "company"),
+	)
+	uc := newOrgUnitUseCase(tree, nil)
+
+	for _, parentID := range []string{"engineering", "backend"} {
+		_, err := uc.UpdateUnit(context.Background(), "engineering", "engineering", &parentID)
+		requireErrorCode(t, err, domain.ErrorCodeValidation)
+	}
+	_, err := uc.UpdateUnit(context.Background(), "company", "company", strPtr("backend"))
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+	_, err = uc.UpdateUnit(context.Background(), "engineering", "engineering", strPtr("ghost"))
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+	assert.Empty(t, tree.updated)
+
+	_, err = uc.UpdateUnit(context.Background(), "engineering", "engineering", strPtr("sales"))
+	require.NoError(t, err)
+	_, err = uc.UpdateUnit(context.Background(), "backend", "backend", nil)
+	require.NoError(t, err)
+	require.Len(t, tree.updated, 2)
+	assert.Equal(t, "sales", *tree.updated[0].ParentID)
+	assert.Nil(t, tree.updated[1].ParentID)
+}
+
+func TestGetStatsSumsSubtreeTeams(t *testing.T) {
+	tree := newOrgUnitTree(
+		orgUnit("engineering", "", "platform"),
+		orgUnit("backend", "engineering", "api", "billing"),
+		orgUnit("sales", "", "crm"),
+	)
+	stats := &orgUnitStatsRepo{stats: map[string]*domain.TeamStats{
+		"platform": {TeamName: "platform", Members: 3, ActiveMembers: 2, OpenPRs: 1, MergedPRs: 4, OpenReviews: 2},
+		"api":      {TeamName: "api", Members: 5, ActiveMembers: 5, OpenPRs: 2, ClosedPRs: 1, OpenReviews: 3, SLABreaches: 1},
+		"billing":  {TeamName: "billing", Members: 1, ActiveMembers: 0, MergedPRs: 2, SLABreaches: 2},
+	}}
+	uc := newOrgUnitUseCase(tree, stats)
+
+	result, err := uc.GetStats(context.Background(), "engineering")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"platform", "api", "billing"}, stats.requested)
+	assert.Len(t, result.Teams, 3)
+	assert.Equal(t, domain.TeamStats{Members: 9, ActiveMembers: 7, OpenPRs: 3, MergedPRs: 6, ClosedPRs: 1, OpenReviews: 5, SLABreaches: 3}, result.Totals)
+
+	_, err = uc.GetStats(context.Background(), "ghost")
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+}
+
+func strPtr(value string) *string {
+	return &value
+}
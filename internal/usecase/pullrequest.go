@@ -8,26 +8,61 @@ import (
 	"avitotest/internal/domain"
 )
 
+const maxReviewers = 2
+
+type CreatePullRequestInput struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	CoAuthorIDs     []string
+	ReviewTeam      string
+	Repository      string
+	ChangedFiles    []string
+	Labels          []string
+}
+
 type PullRequestUseCase struct {
-	prRepo   domain.PullRequestRepository
-	userRepo domain.UserRepository
-	teamRepo domain.TeamRepository
+	prRepo        domain.PullRequestRepository
+	userRepo      domain.UserRepository
+	teamRepo      domain.TeamRepository
+	exclusionRepo domain.ExclusionRepository
+	orgUnitRepo   domain.OrgUnitRepository
+	slaRepo       domain.SLARepository
+	transactor    domain.Transactor
+	events        *eventRecorder
+	codeOwners    *CodeOwnersUseCase
+	random        *rand.Rand
 }
 
 func NewPullRequestUseCase(
 	prRepo domain.PullRequestRepository,
 	userRepo domain.UserRepository,
 	teamRepo domain.TeamRepository,
+	exclusionRepo domain.ExclusionRepository,
+	orgUnitRepo domain.OrgUnitRepository,
+	slaRepo domain.SLARepository,
+	outboxRepo domain.OutboxRepository,
+	transactor domain.Transactor,
+	publisher domain.EventPublisher,
+	codeOwners *CodeOwnersUseCase,
+	random *rand.Rand,
 ) *PullRequestUseCase {
 	return &PullRequestUseCase{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		prRepo:        prRepo,
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		exclusionRepo: exclusionRepo,
+		orgUnitRepo:   orgUnitRepo,
+		slaRepo:       slaRepo,
+		transactor:    transactor,
+		events:        newEventRecorder(outboxRepo, transactor, publisher),
+		codeOwners:    codeOwners,
+		random:        random,
 	}
 }
 
-func (uc *PullRequestUseCase) CreatePullRequest(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error) {
-	exists, err := uc.prRepo.Exists(ctx, prID)
+func (uc *PullRequestUseCase) CreatePullRequest(ctx context.Context, input CreatePullRequestInput) (*domain.PullRequest, error) {
+	exists, err := uc.prRepo.Exists(ctx, input.PullRequestID)
 	if err != nil {
 		return nil, err
 	}
@@ -35,38 +70,62 @@ func (uc *PullRequestUseCase) CreatePullRequest(ctx context.Context, prID, prNam
 		return nil, domain.NewDomainError(domain.ErrorCodePRExists, "PR id already exists")
 	}
 
-	author, err := uc.userRepo.GetByID(ctx, authorID)
+	plan, err := uc.planAssignment(ctx, input, uc.random)
 	if err != nil {
 		return nil, err
 	}
 
-	teamUsers, err := uc.userRepo.GetByTeamName(ctx, author.TeamName)
-	if err != nil {
-		return nil, err
+	pr := &domain.PullRequest{
+		PullRequestID:     input.PullRequestID,
+		PullRequestName:   input.PullRequestName,
+		AuthorID:          input.AuthorID,
+		CoAuthorIDs:       plan.coAuthorIDs,
+		ReviewTeam:        plan.reviewTeam,
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: plan.reviewers,
+		Labels:            plan.labels,
+		Assignment:        plan.explanation,
 	}
 
-	var candidates []*domain.User
-	for _, user := range teamUsers {
-		if user.IsActive && user.UserID != authorID {
-			candidates = append(candidates, user)
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Create(ctx, pr); err != nil {
+			return err
+		}
+		if err := uc.events.record(ctx, domain.EventPRCreated, pr.PullRequestID, domain.PRCreatedPayload{PullRequest: pr}); err != nil {
+			return err
 		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			payload := domain.ReviewerAssignedPayload{PullRequestID: pr.PullRequestID, ReviewerID: reviewerID}
+			if err := uc.events.record(ctx, domain.EventReviewerAssigned, pr.PullRequestID, payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	reviewers := uc.selectReviewers(candidates, 2)
+	return pr, nil
+}
 
-	pr := &domain.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
-		Status:            domain.PRStatusOpen,
-		AssignedReviewers: reviewers,
+func (uc *PullRequestUseCase) PreviewAssignment(ctx context.Context, input CreatePullRequestInput, seed *int64) (*domain.AssignmentPreview, error) {
+	planSeed := uc.random.Int63()
+	if seed != nil {
+		planSeed = *seed
 	}
 
-	if err := uc.prRepo.Create(ctx, pr); err != nil {
+	plan, err := uc.planAssignment(ctx, input, rand.New(rand.NewSource(planSeed)))
+	if err != nil {
 		return nil, err
 	}
+	plan.explanation.Seed = &planSeed
 
-	return pr, nil
+	return &domain.AssignmentPreview{
+		AuthorID:          input.AuthorID,
+		AssignedReviewers: plan.reviewers,
+		Assignment:        plan.explanation,
+	}, nil
 }
 
 func (uc *PullRequestUseCase) MergePullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
@@ -78,18 +137,76 @@ func (uc *PullRequestUseCase) MergePullRequest(ctx context.Context, prID string)
 	if pr.Status == domain.PRStatusMerged {
 		return pr, nil
 	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, domain.NewDomainError(domain.ErrorCodePRClosed, "cannot merge closed PR")
+	}
 
 	now := time.Now()
 	pr.Status = domain.PRStatusMerged
 	pr.MergedAt = &now
 
-	if err := uc.prRepo.Update(ctx, pr); err != nil {
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return uc.events.record(ctx, domain.EventPRMerged, pr.PullRequestID, domain.PRMergedPayload{PullRequest: pr})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (uc *PullRequestUseCase) ClosePullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.PRStatusClosed {
+		return pr, nil
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.NewDomainError(domain.ErrorCodePRMerged, "cannot close merged PR")
+	}
+
+	pr.Status = domain.PRStatusClosed
+	if err := uc.updateStatus(ctx, pr, domain.EventPRClosed); err != nil {
 		return nil, err
 	}
+	return pr, nil
+}
+
+func (uc *PullRequestUseCase) ReopenPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.PRStatusOpen {
+		return pr, nil
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.NewDomainError(domain.ErrorCodePRMerged, "cannot reopen merged PR")
+	}
 
+	pr.Status = domain.PRStatusOpen
+	if err := uc.updateStatus(ctx, pr, domain.EventPRReopened); err != nil {
+		return nil, err
+	}
 	return pr, nil
 }
 
+func (uc *PullRequestUseCase) updateStatus(ctx context.Context, pr *domain.PullRequest, eventType domain.EventType) error {
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return uc.events.record(ctx, eventType, pr.PullRequestID, domain.PRStatusChangedPayload{PullRequest: pr})
+	})
+}
+
 func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*domain.PullRequest, string, error) {
 	pr, err := uc.prRepo.GetByID(ctx, prID)
 	if err != nil {
@@ -98,6 +215,9 @@ func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUse
 	if pr.Status == domain.PRStatusMerged {
 		return nil, "", domain.NewDomainError(domain.ErrorCodePRMerged, "cannot reassign on merged PR")
 	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, "", domain.NewDomainError(domain.ErrorCodePRClosed, "cannot reassign on closed PR")
+	}
 
 	isAssigned := false
 	for _, reviewerID := range pr.AssignedReviewers {
@@ -115,30 +235,90 @@ func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUse
 		return nil, "", err
 	}
 
-	teamUsers, err := uc.userRepo.GetByTeamName(ctx, oldReviewer.TeamName)
+	teamNames, err := uc.reviewTeams(ctx, pr)
 	if err != nil {
 		return nil, "", err
 	}
+	if len(teamNames) == 0 {
+		teamNames = []string{oldReviewer.TeamName}
+	}
+	teamName := teamNames[0]
+
+	var teamUsers []*domain.User
+	for _, name := range teamNames {
+		users, err := uc.userRepo.GetByTeamName(ctx, name)
+		if err != nil {
+			return nil, "", err
+		}
+		teamUsers = append(teamUsers, users...)
+	}
+	teamUsers = excludeUsers(teamUsers, nil)
 
 	asResMap := make(map[string]struct{})
 	for _, user := range pr.AssignedReviewers {
 		asResMap[user] = struct{}{}
 	}
 
-	var candidates []*domain.User
-	for _, user := range teamUsers {
-		if user.IsActive && user.UserID != oldUserID && user.UserID != pr.AuthorID {
-			if _, ok := asResMap[user.UserID]; !ok {
-				candidates = append(candidates, user)
-			}
+	for _, authorID := range pr.AuthorIDs() {
+		asResMap[authorID] = struct{}{}
+	}
+
+	authors := pr.AuthorIDs()
+	excluded, err := uc.excludedReviewers(ctx, authors)
+	if err != nil {
+		return nil, "", err
+	}
+
+	explanation := &domain.AssignmentExplanation{
+		Strategy:   strategyFor(pr.Labels),
+		AssignedAt: time.Now(),
+	}
+
+	candidates, err := uc.replacementCandidates(ctx, pr, oldUserID, teamUsers, excluded, explanation)
+	if hasErrorCode(err, domain.ErrorCodeNoCandidate) {
+		department, derr := uc.departmentPool(ctx, teamName)
+		if derr != nil {
+			return nil, "", derr
+		}
+		if len(department) > 0 {
+			explanation.Filters = nil
+			explanation.FallbackPoolSize = len(department)
+			pool := excludeUsers(append(append([]*domain.User{}, teamUsers...), department...), nil)
+			candidates, err = uc.replacementCandidates(ctx, pr, oldUserID, pool, excluded, explanation)
 		}
 	}
+	if err != nil {
+		return nil, "", err
+	}
 
-	if len(candidates) == 0 {
-		return nil, "", domain.NewDomainError(domain.ErrorCodeNoCandidate, "no active replacement candidate in team")
+	scores, err := uc.expertiseScores(ctx, pr.Labels, candidates)
+	if err != nil {
+		return nil, "", err
 	}
 
-	newReviewerID := uc.selectReviewers(candidates, 1)[0]
+	newReviewerID := uc.selectReviewers(uc.random, candidates, 1, scores)[0]
+
+	previous := make(map[string]domain.ReviewerChoice)
+	if pr.Assignment != nil {
+		for _, choice := range pr.Assignment.Reviewers {
+			previous[choice.UserID] = choice
+		}
+	}
+	for _, reviewerID := range pr.AssignedReviewers {
+		switch choice, ok := previous[reviewerID]; {
+		case reviewerID == oldUserID:
+			explanation.Reviewers = append(explanation.Reviewers, domain.ReviewerChoice{
+				UserID: newReviewerID,
+				Reason: domain.AssignmentReasonReassigned,
+				Score:  scores[newReviewerID],
+			})
+		case ok:
+			explanation.Reviewers = append(explanation.Reviewers, choice)
+		default:
+			explanation.Reviewers = append(explanation.Reviewers, domain.ReviewerChoice{UserID: reviewerID, Reason: domain.AssignmentReasonTeam})
+		}
+	}
+	pr.Assignment = explanation
 
 	for i, reviewerID := range pr.AssignedReviewers {
 		if reviewerID == oldUserID {
@@ -147,13 +327,247 @@ func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUse
 		}
 	}
 
-	if err := uc.prRepo.Update(ctx, pr); err != nil {
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		payload := domain.ReviewerReassignedPayload{
+			PullRequestID: pr.PullRequestID,
+			OldReviewerID: oldUserID,
+			NewReviewerID: newReviewerID,
+		}
+		return uc.events.record(ctx, domain.EventReviewerReassigned, pr.PullRequestID, payload)
+	})
+	if err != nil {
 		return nil, "", err
 	}
 
 	return pr, newReviewerID, nil
 }
 
+func (uc *PullRequestUseCase) pullRequestTeam(ctx context.Context, pr *domain.PullRequest) (string, error) {
+	if pr.ReviewTeam != "" || pr.AuthorID == "" {
+		return pr.ReviewTeam, nil
+	}
+	author, err := uc.userRepo.GetByID(ctx, pr.AuthorID)
+	if hasErrorCode(err, domain.ErrorCodeNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return author.TeamName, nil
+}
+
+func (uc *PullRequestUseCase) reviewTeams(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	if pr.ReviewTeam != "" {
+		return []string{pr.ReviewTeam}, nil
+	}
+
+	var teamNames []string
+	for _, authorID := range pr.AuthorIDs() {
+		author, err := uc.userRepo.GetByID(ctx, authorID)
+		if hasErrorCode(err, domain.ErrorCodeNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if author.TeamName != "" && !containsString(teamNames, author.TeamName) {
+			teamNames = append(teamNames, author.TeamName)
+		}
+	}
+	return teamNames, nil
+}
+
+func (uc *PullRequestUseCase) replacementCandidates(ctx context.Context, pr *domain.PullRequest, oldUserID string, pool []*domain.User, excluded map[string]struct{}, explanation *domain.AssignmentExplanation) ([]*domain.User, error) {
+	authors := pr.AuthorIDs()
+	explanation.PoolSize = len(pool)
+
+	candidates := recordFilter(explanation, "author", pool, func(user *domain.User) bool {
+		return containsString(authors, user.UserID)
+	})
+	candidates = recordFilter(explanation, "inactive", candidates, func(user *domain.User) bool {
+		return !user.IsActive
+	})
+	candidates = recordFilter(explanation, "already_assigned", candidates, func(user *domain.User) bool {
+		return containsString(pr.AssignedReviewers, user.UserID)
+	})
+	if len(candidates) == 0 {
+		return nil, domain.NewDomainError(domain.ErrorCodeNoCandidate, "no active replacement candidate in team")
+	}
+
+	candidates = recordFilter(explanation, "exclusion", candidates, func(user *domain.User) bool {
+		_, ok := excluded[user.UserID]
+		return ok
+	})
+	if len(candidates) == 0 {
+		return nil, domain.NewDomainError(domain.ErrorCodeNoCandidate, "all replacement candidates are excluded from reviewing this author")
+	}
+
+	allowed, err := uc.applyTeamPolicy(ctx, pr, oldUserID, candidates)
+	if err != nil {
+		return nil, err
+	}
+	candidates = recordFilter(explanation, "team_policy", candidates, func(user *domain.User) bool {
+		return !containsUser(allowed, user.UserID)
+	})
+	explanation.EligibleCount = len(candidates)
+	return candidates, nil
+}
+
+func (uc *PullRequestUseCase) UnassignReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(pr.AssignedReviewers, userID) {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+	}
+
+	reviewers := make([]string, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID != userID {
+			reviewers = append(reviewers, reviewerID)
+		}
+	}
+	pr.AssignedReviewers = reviewers
+
+	if pr.Assignment != nil {
+		choices := make([]domain.ReviewerChoice, 0, len(pr.Assignment.Reviewers))
+		for _, choice := range pr.Assignment.Reviewers {
+			if choice.UserID != userID {
+				choices = append(choices, choice)
+			}
+		}
+		pr.Assignment.Reviewers = choices
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		payload := domain.ReviewerUnassignedPayload{PullRequestID: pr.PullRequestID, ReviewerID: userID}
+		return uc.events.record(ctx, domain.EventReviewerUnassigned, pr.PullRequestID, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (uc *PullRequestUseCase) ReleaseReviews(ctx context.Context, userID, teamName string, policy domain.ReviewPolicy) (*domain.ReviewRelease, error) {
+	release := domain.NewReviewRelease()
+	if policy == domain.ReviewPolicyKeep {
+		return release, nil
+	}
+
+	prs, err := uc.prRepo.GetByReviewerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, pr := range prs {
+			if pr.Status != domain.PRStatusOpen {
+				continue
+			}
+			if teamName != "" {
+				teamNames, err := uc.reviewTeams(ctx, pr)
+				if err != nil {
+					return err
+				}
+				if !containsString(teamNames, teamName) {
+					continue
+				}
+			}
+
+			if policy == domain.ReviewPolicyReassign {
+				_, _, err := uc.ReassignReviewer(ctx, pr.PullRequestID, userID)
+				if err == nil {
+					release.Released = append(release.Released, pr.PullRequestID)
+					continue
+				}
+				if !hasErrorCode(err, domain.ErrorCodeNoCandidate) {
+					return err
+				}
+				release.Unassigned = append(release.Unassigned, pr.PullRequestID)
+			}
+			if _, err := uc.UnassignReviewer(ctx, pr.PullRequestID, userID); err != nil {
+				return err
+			}
+			release.Released = append(release.Released, pr.PullRequestID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (uc *PullRequestUseCase) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := uc.pullRequestTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := uc.userRepo.GetByIDs(ctx, pr.AssignedReviewers)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.User, len(users))
+	for _, user := range users {
+		byID[user.UserID] = user
+	}
+
+	reviewers := make([]domain.ReviewerInfo, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewer, ok := byID[reviewerID]
+		if !ok {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		reviewers = append(reviewers, domain.ReviewerInfo{
+			UserID:   reviewer.UserID,
+			Username: reviewer.Username,
+			TeamName: reviewer.TeamName,
+			IsActive: reviewer.IsActive,
+		})
+	}
+
+	breaches, err := uc.slaRepo.ListByPullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	verdicts, err := uc.slaRepo.ListVerdicts(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var age time.Duration
+	if pr.CreatedAt != nil {
+		end := time.Now()
+		if pr.MergedAt != nil {
+			end = *pr.MergedAt
+		}
+		age = end.Sub(*pr.CreatedAt)
+	}
+
+	return &domain.PullRequestDetails{
+		PullRequest: *pr,
+		TeamName:    teamName,
+		Reviewers:   reviewers,
+		AgeSeconds:  int64(age.Seconds()),
+		SLABreaches: breaches,
+		Verdicts:    verdicts,
+	}, nil
+}
+
 func (uc *PullRequestUseCase) GetPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]*domain.PullRequestShort, error) {
 
 	prs, err := uc.prRepo.GetByReviewerID(ctx, reviewerID)
@@ -173,28 +587,3 @@ func (uc *PullRequestUseCase) GetPullRequestsByReviewer(ctx context.Context, rev
 
 	return result, nil
 }
-
-func (uc *PullRequestUseCase) selectReviewers(candidates []*domain.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
-	}
-
-	count := maxCount
-	if len(candidates) < count {
-		count = len(candidates)
-	}
-
-	shuffled := make([]*domain.User, len(candidates))
-	copy(shuffled, candidates)
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	rng.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-
-	reviewers := make([]string, 0, count)
-	for i := 0; i < count; i++ {
-		reviewers = append(reviewers, shuffled[i].UserID)
-	}
-
-	return reviewers
-}
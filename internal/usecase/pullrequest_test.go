@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reviewersPullRequestRepo struct {
+	tenantPullRequestRepo
+	reviewers []string
+}
+
+func (r reviewersPullRequestRepo) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := r.tenantPullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.AssignedReviewers = r.reviewers
+	return pr, nil
+}
+
+type reviewTeamPullRequestRepo struct {
+	tenantPullRequestRepo
+	reviewTeam string
+}
+
+func (r reviewTeamPullRequestRepo) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := r.tenantPullRequestRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.ReviewTeam = r.reviewTeam
+	return pr, nil
+}
+
+type missingUserRepo struct {
+	tenantUserRepo
+	missing string
+}
+
+func (r missingUserRepo) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	users, err := r.tenantUserRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return excludeUsers(users, []string{r.missing}), nil
+}
+
+func TestGetPullRequestLoadsReviewersInOneBatch(t *testing.T) {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+	prUseCase.prRepo = reviewersPullRequestRepo{tenantPullRequestRepo{rec}, []string{"u3", "u2"}}
+
+	details, err := prUseCase.GetPullRequest(context.Background(), "pr1")
+	require.NoError(t, err)
+
+	require.Len(t, details.Reviewers, 2)
+	assert.Equal(t, "u3", details.Reviewers[0].UserID)
+	assert.Equal(t, "u2", details.Reviewers[1].UserID)
+	assert.Equal(t, "backend", details.TeamName)
+	assert.Equal(t, []string{"pullRequests.GetByID", "users.GetByID", "users.GetByIDs", "sla.ListByPullRequest", "sla.ListVerdicts"}, rec.calls)
+}
+
+func TestGetPullRequestFailsOnUnknownReviewer(t *testing.T) {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+	prUseCase.prRepo = reviewersPullRequestRepo{tenantPullRequestRepo{rec}, []string{"u2", "u3"}}
+	prUseCase.userRepo = missingUserRepo{tenantUserRepo{rec}, "u3"}
+
+	_, err := prUseCase.GetPullRequest(context.Background(), "pr1")
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNotFound, domainErr.Code)
+}
+
+func TestGetPullRequestReportsReviewTeam(t *testing.T) {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+	prUseCase.prRepo = reviewTeamPullRequestRepo{tenantPullRequestRepo{rec}, "platform"}
+
+	details, err := prUseCase.GetPullRequest(context.Background(), "pr1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "platform", details.TeamName)
+	assert.NotContains(t, rec.calls, "users.GetByID")
+}
+
+func TestPullRequestOfDeletedAuthor(t *testing.T) {
+	dir := teamDirectory()
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+	uc := newDirectoryUseCase(dir)
+
+	details, err := uc.GetPullRequest(context.Background(), "pr1")
+	require.NoError(t, err)
+	assert.Empty(t, details.TeamName)
+	assert.Empty(t, details.AuthorID)
+
+	_, newReviewerID, err := uc.ReassignReviewer(context.Background(), "pr1", "solo")
+	require.NoError(t, err)
+	assert.Contains(t, []string{"lead", "multi", "guest"}, newReviewerID, "replacements come from the reviewer's team")
+}
+
+func TestReassignReviewerDrawsFromPullRequestTeam(t *testing.T) {
+	tests := []struct {
+		name       string
+		reviewTeam string
+		replacedBy string
+	}{
+		{name: "author's primary team", reviewTeam: "", replacedBy: "guest"},
+		{name: "explicit review team", reviewTeam: "platform", replacedBy: "p1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := teamDirectory()
+			dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "f1", ReviewTeam: tt.reviewTeam, Status: domain.PRStatusOpen, AssignedReviewers: []string{"multi"}}
+			uc := newDirectoryUseCase(dir)
+
+			_, newReviewerID, err := uc.ReassignReviewer(context.Background(), "pr1", "multi")
+			require.NoError(t, err)
+			assert.Equal(t, tt.replacedBy, newReviewerID, "multi's primary team is backend")
+		})
+	}
+}
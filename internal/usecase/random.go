@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"math/rand"
+	"sync"
+)
+
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func NewRandom(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed)})
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type ReminderUseCase struct {
+	userRepo domain.UserRepository
+	prRepo   domain.PullRequestRepository
+}
+
+func NewReminderUseCase(userRepo domain.UserRepository, prRepo domain.PullRequestRepository) *ReminderUseCase {
+	return &ReminderUseCase{
+		userRepo: userRepo,
+		prRepo:   prRepo,
+	}
+}
+
+func (uc *ReminderUseCase) BuildDigests(ctx context.Context) ([]*domain.ReviewDigest, error) {
+	now := time.Now()
+	digests := []*domain.ReviewDigest{}
+
+	for offset := 0; ; offset += maxUsersPageSize {
+		users, total, err := uc.userRepo.List(ctx, domain.UserFilter{Limit: maxUsersPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			digest, err := uc.buildDigest(ctx, user, now)
+			if err != nil {
+				return nil, err
+			}
+			if digest != nil {
+				digests = append(digests, digest)
+			}
+		}
+
+		if len(users) == 0 || offset+len(users) >= total {
+			return digests, nil
+		}
+	}
+}
+
+func (uc *ReminderUseCase) buildDigest(ctx context.Context, user *domain.User, now time.Time) (*domain.ReviewDigest, error) {
+	prs, err := uc.prRepo.GetByReviewerID(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []*domain.PullRequestShort
+	for _, pr := range prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		open = append(open, &domain.PullRequestShort{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+		})
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+
+	return &domain.ReviewDigest{
+		UserID:       user.UserID,
+		Username:     user.Username,
+		Email:        user.Email,
+		TeamName:     user.TeamName,
+		PullRequests: open,
+		GeneratedAt:  now,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDigestsListsOpenAssignedPullRequests(t *testing.T) {
+	rec := &tenantRecorder{}
+	reminderUseCase := NewReminderUseCase(tenantUserRepo{rec}, tenantPullRequestRepo{rec})
+
+	digests, err := reminderUseCase.BuildDigests(domain.WithTenant(context.Background(), "t1"))
+	require.NoError(t, err)
+	require.Len(t, digests, 1)
+
+	assert.Equal(t, "u1", digests[0].UserID)
+	assert.Equal(t, "backend", digests[0].TeamName)
+	require.Len(t, digests[0].PullRequests, 1)
+	assert.Equal(t, "pr1", digests[0].PullRequests[0].PullRequestID)
+	assert.Equal(t, []string{"users.List", "pullRequests.GetByReviewerID"}, rec.calls)
+	assert.Equal(t, []string{"t1", "t1"}, rec.tenants)
+}
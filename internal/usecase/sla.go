@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+func (uc *PullRequestUseCase) SubmitVerdict(ctx context.Context, prID, userID string, verdict domain.ReviewVerdict) (*domain.PullRequest, error) {
+	if !verdict.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "verdict must be one of approved, changes_requested")
+	}
+
+	var pr *domain.PullRequest
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = uc.prRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status == domain.PRStatusMerged {
+			return domain.NewDomainError(domain.ErrorCodePRMerged, "cannot review merged PR")
+		}
+		if pr.Status == domain.PRStatusClosed {
+			return domain.NewDomainError(domain.ErrorCodePRClosed, "cannot review closed PR")
+		}
+		if !containsString(pr.AssignedReviewers, userID) {
+			return domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+		}
+
+		now := time.Now()
+		if err := uc.slaRepo.RecordVerdict(ctx, &domain.SubmittedVerdict{
+			PullRequestID: pr.PullRequestID,
+			ReviewerID:    userID,
+			Verdict:       verdict,
+			SubmittedAt:   now,
+		}); err != nil {
+			return err
+		}
+
+		if pr.FirstVerdictAt != nil {
+			return nil
+		}
+		pr.FirstVerdictAt = &now
+		return uc.prRepo.Update(ctx, pr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (uc *PullRequestUseCase) EscalateSLABreach(ctx context.Context, breach *domain.SLABreach) error {
+	return uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := uc.prRepo.GetByID(ctx, breach.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if pr.Status != domain.PRStatusOpen {
+			return nil
+		}
+
+		backupID, err := uc.backupReviewer(ctx, pr, breach.TeamName)
+		if err != nil {
+			return err
+		}
+		breach.BackupReviewerID = backupID
+		breach.BreachedAt = time.Now()
+
+		recorded, err := uc.slaRepo.RecordBreach(ctx, breach)
+		if err != nil || !recorded {
+			return err
+		}
+
+		if backupID != "" {
+			pr.AssignedReviewers = append(pr.AssignedReviewers, backupID)
+			if pr.Assignment != nil {
+				pr.Assignment.Reviewers = append(pr.Assignment.Reviewers, domain.ReviewerChoice{
+					UserID: backupID,
+					Reason: domain.AssignmentReasonSLABackup,
+				})
+			}
+			if err := uc.prRepo.Update(ctx, pr); err != nil {
+				return err
+			}
+			payload := domain.ReviewerAssignedPayload{PullRequestID: pr.PullRequestID, ReviewerID: backupID}
+			if err := uc.events.record(ctx, domain.EventReviewerAssigned, pr.PullRequestID, payload); err != nil {
+				return err
+			}
+		}
+
+		return uc.events.record(ctx, domain.EventSLABreached, pr.PullRequestID, domain.SLABreachedPayload{
+			PullRequestID:    pr.PullRequestID,
+			TeamName:         breach.TeamName,
+			Kind:             breach.Kind,
+			SLASeconds:       breach.SLASeconds,
+			BackupReviewerID: backupID,
+		})
+	})
+}
+
+func (uc *PullRequestUseCase) backupReviewer(ctx context.Context, pr *domain.PullRequest, teamName string) (string, error) {
+	teamUsers, err := uc.userRepo.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return "", err
+	}
+
+	authors := pr.AuthorIDs()
+	excluded, err := uc.excludedReviewers(ctx, authors)
+	if err != nil {
+		return "", err
+	}
+
+	candidates := filterUsers(teamUsers, func(user *domain.User) bool {
+		_, isExcluded := excluded[user.UserID]
+		return user.IsActive && !isExcluded && !containsString(authors, user.UserID) && !containsString(pr.AssignedReviewers, user.UserID)
+	})
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	scores, err := uc.expertiseScores(ctx, pr.Labels, candidates)
+	if err != nil {
+		return "", err
+	}
+	return uc.selectReviewers(uc.random, candidates, 1, scores)[0], nil
+}
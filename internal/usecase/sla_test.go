@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalateSLABreachAddsBackupReviewer(t *testing.T) {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+
+	breach := &domain.SLABreach{PullRequestID: "pr1", TeamName: "backend", Kind: domain.SLAKindMerge, SLASeconds: 60}
+	require.NoError(t, prUseCase.EscalateSLABreach(context.Background(), breach))
+
+	assert.Equal(t, "u3", breach.BackupReviewerID)
+	assert.False(t, breach.BreachedAt.IsZero())
+	assert.Contains(t, rec.calls, "sla.RecordBreach")
+	assert.Contains(t, rec.calls, "pullRequests.Update")
+
+	published := 0
+	for _, call := range rec.calls {
+		if call == "publisher.Publish" {
+			published++
+		}
+	}
+	assert.Equal(t, 2, published)
+}
+
+func TestSubmitVerdictRequiresAssignedReviewer(t *testing.T) {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+
+	_, err := prUseCase.SubmitVerdict(context.Background(), "pr1", "u3", domain.ReviewVerdictApproved)
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrorCodeNotAssigned, domainErr.Code)
+
+	pr, err := prUseCase.SubmitVerdict(context.Background(), "pr1", "u2", domain.ReviewVerdictApproved)
+	require.NoError(t, err)
+	assert.NotNil(t, pr.FirstVerdictAt)
+}
+
+type verdictSLARepo struct {
+	tenantSLARepo
+	verdicts []*domain.SubmittedVerdict
+}
+
+func (r *verdictSLARepo) RecordVerdict(ctx context.Context, verdict *domain.SubmittedVerdict) error {
+	r.verdicts = append(r.verdicts, verdict)
+	return nil
+}
+
+func TestSubmitVerdictRecordsEveryVerdict(t *testing.T) {
+	rec := &tenantRecorder{}
+	prUseCase := newTenantUseCases(rec)[1].(*PullRequestUseCase)
+	slaRepo := &verdictSLARepo{tenantSLARepo: tenantSLARepo{rec}}
+	prUseCase.slaRepo = slaRepo
+
+	_, err := prUseCase.SubmitVerdict(context.Background(), "pr1", "u2", domain.ReviewVerdictChangesRequested)
+	require.NoError(t, err)
+	_, err = prUseCase.SubmitVerdict(context.Background(), "pr1", "u2", domain.ReviewVerdictApproved)
+	require.NoError(t, err)
+
+	require.Len(t, slaRepo.verdicts, 2)
+	for i, verdict := range []domain.ReviewVerdict{domain.ReviewVerdictChangesRequested, domain.ReviewVerdictApproved} {
+		assert.Equal(t, "pr1", slaRepo.verdicts[i].PullRequestID)
+		assert.Equal(t, "u2", slaRepo.verdicts[i].ReviewerID)
+		assert.Equal(t, verdict, slaRepo.verdicts[i].Verdict)
+		assert.False(t, slaRepo.verdicts[i].SubmittedAt.IsZero())
+	}
+}
@@ -2,23 +2,38 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 
 	"avitotest/internal/domain"
 )
 
 type TeamUseCase struct {
-	teamRepo domain.TeamRepository
-	userRepo domain.UserRepository
+	teamRepo   domain.TeamRepository
+	userRepo   domain.UserRepository
+	transactor domain.Transactor
+	reviews    *PullRequestUseCase
 }
 
-func NewTeamUseCase(teamRepo domain.TeamRepository, userRepo domain.UserRepository) *TeamUseCase {
+func NewTeamUseCase(
+	teamRepo domain.TeamRepository,
+	userRepo domain.UserRepository,
+	transactor domain.Transactor,
+	reviews *PullRequestUseCase,
+) *TeamUseCase {
 	return &TeamUseCase{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		teamRepo:   teamRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+		reviews:    reviews,
 	}
 }
 
 func (uc *TeamUseCase) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	if err := validateMembers(team.Members); err != nil {
+		return nil, err
+	}
+
 	exists, err := uc.teamRepo.Exists(ctx, team.TeamName)
 	if err != nil {
 		return nil, err
@@ -26,6 +41,9 @@ func (uc *TeamUseCase) CreateTeam(ctx context.Context, team *domain.Team) (*doma
 	if exists {
 		return nil, domain.NewDomainError(domain.ErrorCodeTeamExists, "")
 	}
+	if err := uc.rejectArchivedMembers(ctx, team.Members); err != nil {
+		return nil, err
+	}
 
 	err = uc.teamRepo.Create(ctx, team)
 	if err != nil {
@@ -37,3 +55,259 @@ func (uc *TeamUseCase) CreateTeam(ctx context.Context, team *domain.Team) (*doma
 func (uc *TeamUseCase) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	return uc.teamRepo.GetByName(ctx, teamName)
 }
+
+func (uc *TeamUseCase) GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	if _, err := uc.teamRepo.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+	return uc.teamRepo.GetPolicy(ctx, teamName)
+}
+
+func (uc *TeamUseCase) SetPolicy(ctx context.Context, policy *domain.TeamPolicy) (*domain.TeamPolicy, error) {
+	if policy.MinSeniorReviewers < 0 || policy.MinSeniorReviewers > maxReviewers {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, fmt.Sprintf("min_senior_reviewers must be between 0 and %d", maxReviewers))
+	}
+	if policy.FirstVerdictSLASeconds < 0 || policy.MergeSLASeconds < 0 {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "sla durations must not be negative")
+	}
+	if _, err := uc.teamRepo.GetByName(ctx, policy.TeamName); err != nil {
+		return nil, err
+	}
+	policy.LeadReviewLabels = normalizeTags(policy.LeadReviewLabels)
+	if err := uc.teamRepo.SetPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (uc *TeamUseCase) GetChatChannel(ctx context.Context, teamName string) (*domain.TeamChatChannel, error) {
+	if _, err := uc.teamRepo.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+	return uc.teamRepo.GetChatChannel(ctx, teamName)
+}
+
+func (uc *TeamUseCase) SetChatChannel(ctx context.Context, channel *domain.TeamChatChannel) (*domain.TeamChatChannel, error) {
+	if channel.WebhookURL != "" {
+		parsed, err := url.Parse(channel.WebhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, domain.NewDomainError(domain.ErrorCodeValidation, "webhook_url must be an absolute http(s) URL")
+		}
+	}
+	if _, err := uc.teamRepo.GetByName(ctx, channel.TeamName); err != nil {
+		return nil, err
+	}
+	if err := uc.teamRepo.SetChatChannel(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (uc *TeamUseCase) SetMemberRole(ctx context.Context, teamName, userID string, role domain.TeamRole) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "role must be one of member, lead")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(user.Teams, teamName) {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user is not a member of this team")
+	}
+	if user.TeamName != teamName {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "role can only be set in the user's primary team")
+	}
+
+	if err := uc.userRepo.SetRole(ctx, userID, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+	return user, nil
+}
+
+func (uc *TeamUseCase) AddMembers(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
+	if err := validateMembers(members); err != nil {
+		return nil, err
+	}
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.teamRepo.GetByName(ctx, teamName); err != nil {
+			return err
+		}
+
+		if err := uc.rejectArchivedMembers(ctx, members); err != nil {
+			return err
+		}
+
+		return uc.teamRepo.Create(ctx, &domain.Team{TeamName: teamName, Members: members})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetByName(ctx, teamName)
+}
+
+func (uc *TeamUseCase) rejectArchivedMembers(ctx context.Context, members []domain.TeamMember) error {
+	for _, member := range members {
+		user, err := uc.userRepo.GetByID(ctx, member.UserID)
+		if err != nil && !hasErrorCode(err, domain.ErrorCodeNotFound) {
+			return err
+		}
+		if user != nil && user.ArchivedAt != nil {
+			return domain.NewDomainError(domain.ErrorCodeValidation, fmt.Sprintf("user %s is archived", user.UserID))
+		}
+	}
+	return nil
+}
+
+func (uc *TeamUseCase) RemoveMembers(ctx context.Context, teamName string, userIDs []string, policy domain.ReviewPolicy) (*domain.ReviewRelease, error) {
+	policy, err := reviewPolicyOrDefault(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	release := domain.NewReviewRelease()
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, userID := range userIDs {
+			user, err := uc.userRepo.GetByID(ctx, userID)
+			if err != nil {
+				return err
+			}
+			if !containsString(user.Teams, teamName) {
+				return domain.NewDomainError(domain.ErrorCodeNotFound, fmt.Sprintf("user %s is not a member of this team", userID))
+			}
+
+			released, err := uc.leaveTeam(ctx, user, teamName, policy)
+			if err != nil {
+				return err
+			}
+			release.Add(released)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (uc *TeamUseCase) RenameTeam(ctx context.Context, teamName, newTeamName string) (*domain.Team, error) {
+	if newTeamName == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "new_team_name is required")
+	}
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.teamRepo.GetByName(ctx, teamName); err != nil {
+			return err
+		}
+		exists, err := uc.teamRepo.Exists(ctx, newTeamName)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domain.NewDomainError(domain.ErrorCodeTeamExists, "team with the new name already exists")
+		}
+		return uc.teamRepo.Rename(ctx, teamName, newTeamName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetByName(ctx, newTeamName)
+}
+
+func (uc *TeamUseCase) DeleteTeam(ctx context.Context, teamName string, policy domain.ReviewPolicy) (*domain.ReviewRelease, error) {
+	policy, err := reviewPolicyOrDefault(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	release := domain.NewReviewRelease()
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		members, err := uc.userRepo.GetByTeamName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+		}
+
+		// Everyone leaves up front, so reassigned reviews go to the department instead of to members who leave next.
+		for _, member := range members {
+			if err := uc.userRepo.RemoveMembership(ctx, member.UserID, teamName); err != nil {
+				return err
+			}
+		}
+		for _, member := range members {
+			released, err := uc.leaveTeam(ctx, member, teamName, policy)
+			if err != nil {
+				return err
+			}
+			release.Add(released)
+		}
+		return uc.teamRepo.DeleteSettings(ctx, teamName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (uc *TeamUseCase) leaveTeam(ctx context.Context, user *domain.User, teamName string, policy domain.ReviewPolicy) (*domain.ReviewRelease, error) {
+	released, err := uc.reviews.ReleaseReviews(ctx, user.UserID, teamName, policy)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.RemoveMembership(ctx, user.UserID, teamName); err != nil {
+		return nil, err
+	}
+	if user.TeamName != teamName {
+		return released, nil
+	}
+
+	var remaining []string
+	for _, name := range user.Teams {
+		if name != teamName {
+			remaining = append(remaining, name)
+		}
+	}
+
+	user.TeamName = ""
+	user.Role = domain.TeamRoleMember
+	if len(remaining) > 0 {
+		user.TeamName = remaining[0]
+	} else {
+		user.IsActive = false
+	}
+	if err := uc.userRepo.CreateOrUpdate(ctx, user); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+func validateMembers(members []domain.TeamMember) error {
+	for _, member := range members {
+		if member.Seniority != "" && !member.Seniority.IsValid() {
+			return domain.NewDomainError(domain.ErrorCodeValidation, fmt.Sprintf("invalid seniority %q", member.Seniority))
+		}
+		if member.Role != "" && !member.Role.IsValid() {
+			return domain.NewDomainError(domain.ErrorCodeValidation, fmt.Sprintf("invalid role %q", member.Role))
+		}
+		if member.Email != "" && !isValidEmail(member.Email) {
+			return domain.NewDomainError(domain.ErrorCodeValidation, fmt.Sprintf("invalid email %q", member.Email))
+		}
+	}
+	return nil
+}
+
+func reviewPolicyOrDefault(policy domain.ReviewPolicy) (domain.ReviewPolicy, error) {
+	if policy == "" {
+		return domain.ReviewPolicyKeep, nil
+	}
+	if !policy.IsValid() {
+		return "", domain.NewDomainError(domain.ErrorCodeValidation, "review_policy must be one of keep, reassign, unassign")
+	}
+	return policy, nil
+}
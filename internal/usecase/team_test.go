@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireErrorCode(t *testing.T, err error, code domain.ErrorCode) {
+	t.Helper()
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, code, domainErr.Code)
+}
+
+func teamDirectory() *directory {
+	dir := newDirectory(
+		lead("lead", "backend", domain.SenioritySenior),
+		member("solo", "backend", domain.SeniorityMiddle),
+		member("multi", "backend", domain.SeniorityMiddle),
+		member("guest", "frontend", domain.SeniorityMiddle),
+		member("f1", "frontend", domain.SeniorityMiddle),
+		member("p1", "platform", domain.SeniorityMiddle),
+	)
+	dir.users["multi"].Teams = []string{"backend", "platform", "frontend"}
+	dir.users["guest"].Teams = []string{"frontend", "backend"}
+	dir.policies["backend"] = &domain.TeamPolicy{TeamName: "backend", MinSeniorReviewers: 1}
+	return dir
+}
+
+func TestRenameTeamMovesMembersAndSettings(t *testing.T) {
+	dir := teamDirectory()
+	uc := newDirectoryTeamUseCase(dir)
+
+	team, err := uc.RenameTeam(context.Background(), "backend", "core")
+	require.NoError(t, err)
+	assert.Equal(t, "core", team.TeamName)
+	assert.Len(t, team.Members, 4)
+
+	assert.Equal(t, "core", dir.users["lead"].TeamName)
+	assert.Equal(t, domain.TeamRoleLead, dir.users["lead"].Role)
+	assert.Equal(t, []string{"core", "platform", "frontend"}, dir.users["multi"].Teams)
+	assert.Equal(t, "frontend", dir.users["guest"].TeamName)
+	assert.Equal(t, []string{"frontend", "core"}, dir.users["guest"].Teams)
+	assert.NotContains(t, dir.policies, "backend")
+	assert.Equal(t, 1, dir.policies["core"].MinSeniorReviewers)
+}
+
+func TestRenameTeamValidatesNames(t *testing.T) {
+	uc := newDirectoryTeamUseCase(teamDirectory())
+
+	_, err := uc.RenameTeam(context.Background(), "backend", "")
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, err = uc.RenameTeam(context.Background(), "ghost", "core")
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+
+	_, err = uc.RenameTeam(context.Background(), "backend", "frontend")
+	requireErrorCode(t, err, domain.ErrorCodeTeamExists)
+}
+
+func TestDeleteTeamMovesOrDeactivatesPrimaryMembers(t *testing.T) {
+	dir := teamDirectory()
+	uc := newDirectoryTeamUseCase(dir)
+
+	released, err := uc.DeleteTeam(context.Background(), "backend", "")
+	require.NoError(t, err)
+	assert.Empty(t, released.Released)
+
+	assert.Equal(t, "platform", dir.users["multi"].TeamName, "primary team falls back to the first remaining membership")
+	assert.Equal(t, []string{"platform", "frontend"}, dir.users["multi"].Teams)
+	assert.True(t, dir.users["multi"].IsActive)
+
+	assert.Empty(t, dir.users["lead"].TeamName)
+	assert.Empty(t, dir.users["lead"].Teams)
+	assert.Equal(t, domain.TeamRoleMember, dir.users["lead"].Role)
+	assert.False(t, dir.users["lead"].IsActive)
+	assert.False(t, dir.users["solo"].IsActive)
+
+	assert.Equal(t, "frontend", dir.users["guest"].TeamName)
+	assert.Equal(t, []string{"frontend"}, dir.users["guest"].Teams)
+	assert.True(t, dir.users["guest"].IsActive)
+
+	assert.NotContains(t, dir.policies, "backend")
+}
+
+func TestDeleteTeamValidatesInput(t *testing.T) {
+	uc := newDirectoryTeamUseCase(teamDirectory())
+
+	_, err := uc.DeleteTeam(context.Background(), "backend", "drop")
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, err = uc.DeleteTeam(context.Background(), "ghost", "")
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+}
+
+func TestDeleteTeamUnassignsOpenReviews(t *testing.T) {
+	dir := teamDirectory()
+	dir.prs["open"] = &domain.PullRequest{PullRequestID: "open", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo", "guest"}}
+	dir.prs["merged"] = &domain.PullRequest{PullRequestID: "merged", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusMerged, AssignedReviewers: []string{"solo"}}
+	dir.prs["other"] = &domain.PullRequest{PullRequestID: "other", AuthorID: "f1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+	uc := newDirectoryTeamUseCase(dir)
+
+	released, err := uc.DeleteTeam(context.Background(), "backend", domain.ReviewPolicyUnassign)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"open"}, released.Released)
+	assert.Empty(t, dir.prs["open"].AssignedReviewers, "secondary members release the team's reviews too")
+	assert.Equal(t, []string{"solo"}, dir.prs["merged"].AssignedReviewers)
+	assert.Equal(t, []string{"solo"}, dir.prs["other"].AssignedReviewers, "reviews of other teams' PRs are kept")
+}
+
+func TestDeleteTeamReassignsReviewsWithinDepartment(t *testing.T) {
+	dir := teamDirectory()
+	delete(dir.policies, "backend")
+	dir.teamUnits["backend"] = "eng"
+	dir.teamUnits["platform"] = "eng"
+	dir.unitTeams["eng"] = []string{"backend", "platform"}
+	dir.prs["open"] = &domain.PullRequest{PullRequestID: "open", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo", "guest"}}
+	uc := newDirectoryTeamUseCase(dir)
+
+	released, err := uc.DeleteTeam(context.Background(), "backend", domain.ReviewPolicyReassign)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"open"}, released.Released)
+	assert.Empty(t, released.Unassigned)
+	assert.ElementsMatch(t, []string{"multi", "p1"}, dir.prs["open"].AssignedReviewers)
+}
+
+func TestDeleteTeamUnassignsWhenDepartmentHasNoCandidates(t *testing.T) {
+	dir := teamDirectory()
+	dir.prs["open"] = &domain.PullRequest{PullRequestID: "open", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+	uc := newDirectoryTeamUseCase(dir)
+
+	released, err := uc.DeleteTeam(context.Background(), "backend", domain.ReviewPolicyReassign)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"open"}, released.Unassigned)
+	assert.Empty(t, dir.prs["open"].AssignedReviewers)
+}
+
+func TestRemoveMembersAppliesReviewPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    domain.ReviewPolicy
+		released  []string
+		reviewers [][]string
+	}{
+		{name: "default keeps reviews", policy: "", released: []string{}, reviewers: [][]string{{"solo"}}},
+		{name: "keep", policy: domain.ReviewPolicyKeep, released: []string{}, reviewers: [][]string{{"solo"}}},
+		{name: "unassign", policy: domain.ReviewPolicyUnassign, released: []string{"pr1"}, reviewers: [][]string{{}}},
+		{name: "reassign", policy: domain.ReviewPolicyReassign, released: []string{"pr1"}, reviewers: [][]string{{"multi"}, {"guest"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := teamDirectory()
+			delete(dir.users, "lead")
+			delete(dir.policies, "backend")
+			dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+			uc := newDirectoryTeamUseCase(dir)
+
+			released, err := uc.RemoveMembers(context.Background(), "backend", []string{"solo"}, tt.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.released, released.Released)
+			assert.Contains(t, tt.reviewers, dir.prs["pr1"].AssignedReviewers)
+			assert.False(t, dir.users["solo"].IsActive)
+			assert.Empty(t, dir.users["solo"].Teams)
+		})
+	}
+}
+
+func TestRemoveMembersDropsSecondaryMembershipOnly(t *testing.T) {
+	dir := teamDirectory()
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "f1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"guest"}}
+	dir.prs["pr2"] = &domain.PullRequest{PullRequestID: "pr2", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"guest"}}
+	uc := newDirectoryTeamUseCase(dir)
+
+	released, err := uc.RemoveMembers(context.Background(), "backend", []string{"guest"}, domain.ReviewPolicyUnassign)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr2"}, released.Released)
+	assert.Equal(t, "frontend", dir.users["guest"].TeamName)
+	assert.Equal(t, []string{"frontend"}, dir.users["guest"].Teams)
+	assert.True(t, dir.users["guest"].IsActive)
+	assert.Equal(t, []string{"guest"}, dir.prs["pr1"].AssignedReviewers, "reviews of the primary team's PRs are kept")
+	assert.Empty(t, dir.prs["pr2"].AssignedReviewers)
+
+	_, err = uc.RemoveMembers(context.Background(), "backend", []string{"f1"}, "")
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+}
+
+func TestRenameTeamKeepsPullRequestReviewTeam(t *testing.T) {
+	dir := teamDirectory()
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+	uc := newDirectoryTeamUseCase(dir)
+
+	_, err := uc.RenameTeam(context.Background(), "backend", "core")
+	require.NoError(t, err)
+	assert.Equal(t, "core", dir.prs["pr1"].ReviewTeam)
+
+	_, newReviewerID, err := uc.reviews.ReassignReviewer(context.Background(), "pr1", "solo")
+	require.NoError(t, err)
+	assert.Contains(t, []string{"lead", "multi", "guest"}, newReviewerID)
+}
+
+func TestDeleteTeamClearsPullRequestReviewTeam(t *testing.T) {
+	dir := teamDirectory()
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"guest"}}
+	uc := newDirectoryTeamUseCase(dir)
+
+	_, err := uc.DeleteTeam(context.Background(), "backend", "")
+	require.NoError(t, err)
+	assert.Empty(t, dir.prs["pr1"].ReviewTeam, "the PR falls back to the author's primary team")
+
+	_, newReviewerID, err := uc.reviews.ReassignReviewer(context.Background(), "pr1", "guest")
+	require.NoError(t, err)
+	assert.Equal(t, "multi", newReviewerID)
+}
@@ -0,0 +1,696 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantRecorder struct {
+	mu      sync.Mutex
+	tenants []string
+	calls   []string
+}
+
+func (r *tenantRecorder) seen(ctx context.Context, call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenants = append(r.tenants, domain.TenantFromContext(ctx))
+	r.calls = append(r.calls, call)
+}
+
+func (r *tenantRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenants = nil
+	r.calls = nil
+}
+
+func testUser(userID string) *domain.User {
+	return &domain.User{
+		UserID:    userID,
+		Username:  userID,
+		TeamName:  "backend",
+		IsActive:  true,
+		Seniority: domain.SenioritySenior,
+		Role:      domain.TeamRoleMember,
+		Teams:     []string{"backend"},
+	}
+}
+
+func testPullRequest(prID string) *domain.PullRequest {
+	return &domain.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   prID,
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+}
+
+type tenantUserRepo struct{ *tenantRecorder }
+
+func (r tenantUserRepo) CreateOrUpdate(ctx context.Context, user *domain.User) error {
+	r.seen(ctx, "users.CreateOrUpdate")
+	return nil
+}
+
+func (r tenantUserRepo) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	r.seen(ctx, "users.GetByID")
+	return testUser(userID), nil
+}
+
+func (r tenantUserRepo) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	r.seen(ctx, "users.GetByIDs")
+	users := make([]*domain.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		users = append(users, testUser(userID))
+	}
+	return users, nil
+}
+
+func (r tenantUserRepo) GetByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
+	r.seen(ctx, "users.GetByTeamName")
+	return []*domain.User{testUser("u1"), testUser("u2"), testUser("u3")}, nil
+}
+
+func (r tenantUserRepo) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int, error) {
+	r.seen(ctx, "users.List")
+	return []*domain.User{testUser("u1")}, 1, nil
+}
+
+func (r tenantUserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	r.seen(ctx, "users.SetIsActive")
+	return nil
+}
+
+func (r tenantUserRepo) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) error {
+	r.seen(ctx, "users.SetSeniority")
+	return nil
+}
+
+func (r tenantUserRepo) SetRole(ctx context.Context, userID string, role domain.TeamRole) error {
+	r.seen(ctx, "users.SetRole")
+	return nil
+}
+
+func (r tenantUserRepo) Archive(ctx context.Context, userID string) error {
+	r.seen(ctx, "users.Archive")
+	return nil
+}
+
+func (r tenantUserRepo) AddMembership(ctx context.Context, userID, teamName string) error {
+	r.seen(ctx, "users.AddMembership")
+	return nil
+}
+
+func (r tenantUserRepo) RemoveMembership(ctx context.Context, userID, teamName string) error {
+	r.seen(ctx, "users.RemoveMembership")
+	return nil
+}
+
+func (r tenantUserRepo) GetTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	r.seen(ctx, "users.GetTags")
+	return map[string][]string{}, nil
+}
+
+func (r tenantUserRepo) SetTags(ctx context.Context, userID string, tags []string) error {
+	r.seen(ctx, "users.SetTags")
+	return nil
+}
+
+func (r tenantUserRepo) ListTenantIDs(ctx context.Context) ([]string, error) {
+	r.seen(ctx, "users.ListTenantIDs")
+	return []string{"t1"}, nil
+}
+
+type tenantTeamRepo struct{ *tenantRecorder }
+
+func (r tenantTeamRepo) Create(ctx context.Context, team *domain.Team) error {
+	r.seen(ctx, "teams.Create")
+	return nil
+}
+
+func (r tenantTeamRepo) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	r.seen(ctx, "teams.GetByName")
+	team := &domain.Team{TeamName: teamName}
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		team.Members = append(team.Members, domain.TeamMember{UserID: userID, Username: userID, IsActive: true, IsPrimary: true})
+	}
+	return team, nil
+}
+
+func (r tenantTeamRepo) Exists(ctx context.Context, teamName string) (bool, error) {
+	r.seen(ctx, "teams.Exists")
+	return true, nil
+}
+
+func (r tenantTeamRepo) GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	r.seen(ctx, "teams.GetPolicy")
+	return &domain.TeamPolicy{TeamName: teamName}, nil
+}
+
+func (r tenantTeamRepo) SetPolicy(ctx context.Context, policy *domain.TeamPolicy) error {
+	r.seen(ctx, "teams.SetPolicy")
+	return nil
+}
+
+func (r tenantTeamRepo) GetChatChannel(ctx context.Context, teamName string) (*domain.TeamChatChannel, error) {
+	r.seen(ctx, "teams.GetChatChannel")
+	return &domain.TeamChatChannel{TeamName: teamName}, nil
+}
+
+func (r tenantTeamRepo) SetChatChannel(ctx context.Context, channel *domain.TeamChatChannel) error {
+	r.seen(ctx, "teams.SetChatChannel")
+	return nil
+}
+
+func (r tenantTeamRepo) Rename(ctx context.Context, teamName, newTeamName string) error {
+	r.seen(ctx, "teams.Rename")
+	return nil
+}
+
+func (r tenantTeamRepo) DeleteSettings(ctx context.Context, teamName string) error {
+	r.seen(ctx, "teams.DeleteSettings")
+	return nil
+}
+
+type tenantPullRequestRepo struct{ *tenantRecorder }
+
+func (r tenantPullRequestRepo) Create(ctx context.Context, pr *domain.PullRequest) error {
+	r.seen(ctx, "pullRequests.Create")
+	return nil
+}
+
+func (r tenantPullRequestRepo) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	r.seen(ctx, "pullRequests.GetByID")
+	return testPullRequest(prID), nil
+}
+
+func (r tenantPullRequestRepo) GetByReviewerID(ctx context.Context, reviewerID string) ([]*domain.PullRequest, error) {
+	r.seen(ctx, "pullRequests.GetByReviewerID")
+	return []*domain.PullRequest{testPullRequest("pr1")}, nil
+}
+
+func (r tenantPullRequestRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
+	r.seen(ctx, "pullRequests.Update")
+	return nil
+}
+
+func (r tenantPullRequestRepo) Exists(ctx context.Context, prID string) (bool, error) {
+	r.seen(ctx, "pullRequests.Exists")
+	return false, nil
+}
+
+type tenantOutboxRepo struct{ *tenantRecorder }
+
+func (r tenantOutboxRepo) Append(ctx context.Context, events ...*domain.Event) error {
+	r.seen(ctx, "outbox.Append")
+	for _, event := range events {
+		r.seen(domain.WithTenant(context.Background(), event.TenantID), "outbox.Append.event")
+	}
+	return nil
+}
+
+func (r tenantOutboxRepo) GetByID(ctx context.Context, eventID int64) (*domain.Event, error) {
+	r.seen(ctx, "outbox.GetByID")
+	return &domain.Event{EventID: eventID, EventType: domain.EventPRCreated}, nil
+}
+
+func (r tenantOutboxRepo) ListAfter(ctx context.Context, afterID int64, eventTypes []domain.EventType, limit int) ([]*domain.Event, error) {
+	r.seen(ctx, "outbox.ListAfter")
+	return nil, nil
+}
+
+func (r tenantOutboxRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+	r.seen(ctx, "outbox.ClaimDue")
+	return nil, nil
+}
+
+func (r tenantOutboxRepo) MarkDelivered(ctx context.Context, eventID int64) error {
+	r.seen(ctx, "outbox.MarkDelivered")
+	return nil
+}
+
+func (r tenantOutboxRepo) MarkFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	r.seen(ctx, "outbox.MarkFailed")
+	return nil
+}
+
+type tenantWebhookRepo struct{ *tenantRecorder }
+
+func (r tenantWebhookRepo) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	r.seen(ctx, "webhooks.CreateSubscription")
+	return nil
+}
+
+func (r tenantWebhookRepo) GetSubscription(ctx context.Context, subscriptionID int64) (*domain.WebhookSubscription, error) {
+	r.seen(ctx, "webhooks.GetSubscription")
+	return &domain.WebhookSubscription{SubscriptionID: subscriptionID, URL: "http://example.com", IsActive: true}, nil
+}
+
+func (r tenantWebhookRepo) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	r.seen(ctx, "webhooks.ListSubscriptions")
+	return []*domain.WebhookSubscription{{SubscriptionID: 1, URL: "http://example.com", IsActive: true}}, nil
+}
+
+func (r tenantWebhookRepo) UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	r.seen(ctx, "webhooks.UpdateSubscription")
+	return nil
+}
+
+func (r tenantWebhookRepo) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	r.seen(ctx, "webhooks.DeleteSubscription")
+	return nil
+}
+
+func (r tenantWebhookRepo) CreateDelivery(ctx context.Context, subscriptionID, eventID int64) (*domain.WebhookDelivery, error) {
+	r.seen(ctx, "webhooks.CreateDelivery")
+	return &domain.WebhookDelivery{DeliveryID: 1, SubscriptionID: subscriptionID, EventID: eventID}, nil
+}
+
+func (r tenantWebhookRepo) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	r.seen(ctx, "webhooks.ListDeliveries")
+	return []*domain.WebhookDelivery{{DeliveryID: 1, SubscriptionID: subscriptionID}}, nil
+}
+
+func (r tenantWebhookRepo) ListAttempts(ctx context.Context, deliveryIDs []int64) ([]*domain.DeliveryAttempt, error) {
+	r.seen(ctx, "webhooks.ListAttempts")
+	return nil, nil
+}
+
+func (r tenantWebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDeliveryTask, error) {
+	r.seen(ctx, "webhooks.ClaimDueDeliveries")
+	return nil, nil
+}
+
+func (r tenantWebhookRepo) RecordAttempt(ctx context.Context, attempt *domain.DeliveryAttempt) error {
+	r.seen(ctx, "webhooks.RecordAttempt")
+	return nil
+}
+
+func (r tenantWebhookRepo) MarkDeliveryDelivered(ctx context.Context, deliveryID int64) error {
+	r.seen(ctx, "webhooks.MarkDeliveryDelivered")
+	return nil
+}
+
+func (r tenantWebhookRepo) MarkDeliveryFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	r.seen(ctx, "webhooks.MarkDeliveryFailed")
+	return nil
+}
+
+type tenantIntegrationRepo struct{ *tenantRecorder }
+
+func (r tenantIntegrationRepo) LinkIdentity(ctx context.Context, identity *domain.ExternalIdentity) error {
+	r.seen(ctx, "integrations.LinkIdentity")
+	return nil
+}
+
+func (r tenantIntegrationRepo) UnlinkIdentity(ctx context.Context, provider domain.CodeHostProvider, externalLogin string) error {
+	r.seen(ctx, "integrations.UnlinkIdentity")
+	return nil
+}
+
+func (r tenantIntegrationRepo) GetUserIDByLogin(ctx context.Context, provider domain.CodeHostProvider, externalLogin string) (string, error) {
+	r.seen(ctx, "integrations.GetUserIDByLogin")
+	return "u1", nil
+}
+
+func (r tenantIntegrationRepo) GetLoginByUserID(ctx context.Context, provider domain.CodeHostProvider, userID string) (string, error) {
+	r.seen(ctx, "integrations.GetLoginByUserID")
+	return userID, nil
+}
+
+func (r tenantIntegrationRepo) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*domain.ExternalIdentity, error) {
+	r.seen(ctx, "integrations.ListIdentitiesByUserID")
+	return []*domain.ExternalIdentity{{Provider: domain.ProviderGitHub, ExternalLogin: userID, UserID: userID}}, nil
+}
+
+func (r tenantIntegrationRepo) LinkPullRequest(ctx context.Context, link *domain.ExternalPullRequest) error {
+	r.seen(ctx, "integrations.LinkPullRequest")
+	return nil
+}
+
+func (r tenantIntegrationRepo) GetPullRequestLink(ctx context.Context, provider domain.CodeHostProvider, repository string, number int) (*domain.ExternalPullRequest, error) {
+	r.seen(ctx, "integrations.GetPullRequestLink")
+	return &domain.ExternalPullRequest{PullRequestID: "pr1", Provider: provider, Repository: repository, Number: number}, nil
+}
+
+func (r tenantIntegrationRepo) GetPullRequestLinkByID(ctx context.Context, prID string) (*domain.ExternalPullRequest, error) {
+	r.seen(ctx, "integrations.GetPullRequestLinkByID")
+	return &domain.ExternalPullRequest{PullRequestID: prID, Provider: domain.ProviderGitHub, Repository: "org/repo", Number: 1}, nil
+}
+
+type tenantCodeOwnersRepo struct{ *tenantRecorder }
+
+func (r tenantCodeOwnersRepo) Upsert(ctx context.Context, ruleset *domain.CodeOwnersRuleset) error {
+	r.seen(ctx, "codeowners.Upsert")
+	return nil
+}
+
+func (r tenantCodeOwnersRepo) Get(ctx context.Context, scope domain.CodeOwnersScope, scopeName string) (*domain.CodeOwnersRuleset, error) {
+	r.seen(ctx, "codeowners.Get")
+	return &domain.CodeOwnersRuleset{Scope: scope, ScopeName: scopeName, Content: "* @u3"}, nil
+}
+
+type tenantExclusionRepo struct{ *tenantRecorder }
+
+func (r tenantExclusionRepo) Upsert(ctx context.Context, exclusion *domain.ReviewerExclusion) error {
+	r.seen(ctx, "exclusions.Upsert")
+	return nil
+}
+
+func (r tenantExclusionRepo) Delete(ctx context.Context, exclusionID int64) error {
+	r.seen(ctx, "exclusions.Delete")
+	return nil
+}
+
+func (r tenantExclusionRepo) ListByUserID(ctx context.Context, userID string) ([]*domain.ReviewerExclusion, error) {
+	r.seen(ctx, "exclusions.ListByUserID")
+	return []*domain.ReviewerExclusion{}, nil
+}
+
+func (r tenantExclusionRepo) GetExcludedReviewers(ctx context.Context, authorIDs []string) ([]string, error) {
+	r.seen(ctx, "exclusions.GetExcludedReviewers")
+	return nil, nil
+}
+
+type tenantOrgUnitRepo struct{ *tenantRecorder }
+
+func (r tenantOrgUnitRepo) Create(ctx context.Context, unit *domain.OrgUnit) error {
+	r.seen(ctx, "orgUnits.Create")
+	return nil
+}
+
+func (r tenantOrgUnitRepo) Get(ctx context.Context, unitID string) (*domain.OrgUnit, error) {
+	r.seen(ctx, "orgUnits.Get")
+	return &domain.OrgUnit{UnitID: unitID, Name: unitID}, nil
+}
+
+func (r tenantOrgUnitRepo) List(ctx context.Context) ([]*domain.OrgUnit, error) {
+	r.seen(ctx, "orgUnits.List")
+	return []*domain.OrgUnit{{UnitID: "unit1", Name: "unit1"}}, nil
+}
+
+func (r tenantOrgUnitRepo) Update(ctx context.Context, unit *domain.OrgUnit) error {
+	r.seen(ctx, "orgUnits.Update")
+	return nil
+}
+
+func (r tenantOrgUnitRepo) Delete(ctx context.Context, unitID string) error {
+	r.seen(ctx, "orgUnits.Delete")
+	return nil
+}
+
+func (r tenantOrgUnitRepo) GetAncestorIDs(ctx context.Context, unitID string) ([]string, error) {
+	r.seen(ctx, "orgUnits.GetAncestorIDs")
+	return nil, nil
+}
+
+func (r tenantOrgUnitRepo) SetTeamUnit(ctx context.Context, teamName string, unitID *string) error {
+	r.seen(ctx, "orgUnits.SetTeamUnit")
+	return nil
+}
+
+func (r tenantOrgUnitRepo) GetUnitIDByTeam(ctx context.Context, teamName string) (string, error) {
+	r.seen(ctx, "orgUnits.GetUnitIDByTeam")
+	return "unit1", nil
+}
+
+func (r tenantOrgUnitRepo) GetSubtreeTeams(ctx context.Context, unitID string) ([]string, error) {
+	r.seen(ctx, "orgUnits.GetSubtreeTeams")
+	return []string{"backend", "frontend"}, nil
+}
+
+type tenantSLARepo struct{ *tenantRecorder }
+
+func (r tenantSLARepo) FindBreaches(ctx context.Context, now time.Time, limit int) ([]*domain.SLABreach, error) {
+	r.seen(ctx, "sla.FindBreaches")
+	return nil, nil
+}
+
+func (r tenantSLARepo) RecordBreach(ctx context.Context, breach *domain.SLABreach) (bool, error) {
+	r.seen(ctx, "sla.RecordBreach")
+	return true, nil
+}
+
+func (r tenantSLARepo) ListByPullRequest(ctx context.Context, prID string) ([]*domain.SLABreach, error) {
+	r.seen(ctx, "sla.ListByPullRequest")
+	return []*domain.SLABreach{}, nil
+}
+
+func (r tenantSLARepo) RecordVerdict(ctx context.Context, verdict *domain.SubmittedVerdict) error {
+	r.seen(ctx, "sla.RecordVerdict")
+	return nil
+}
+
+func (r tenantSLARepo) ListVerdicts(ctx context.Context, prID string) ([]*domain.SubmittedVerdict, error) {
+	r.seen(ctx, "sla.ListVerdicts")
+	return []*domain.SubmittedVerdict{}, nil
+}
+
+type tenantStatsRepo struct{ *tenantRecorder }
+
+func (r tenantStatsRepo) GetTeamStats(ctx context.Context, teamNames []string) ([]*domain.TeamStats, error) {
+	r.seen(ctx, "stats.GetTeamStats")
+	return []*domain.TeamStats{{TeamName: "backend"}}, nil
+}
+
+type tenantTransactor struct{ *tenantRecorder }
+
+func (r tenantTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.seen(ctx, "transactor.WithinTransaction")
+	return fn(ctx)
+}
+
+func (r tenantTransactor) AfterCommit(ctx context.Context, fn func()) {
+	r.seen(ctx, "transactor.AfterCommit")
+	fn()
+}
+
+type tenantPublisher struct{ *tenantRecorder }
+
+func (r tenantPublisher) Publish(ctx context.Context, event *domain.Event) {
+	r.seen(ctx, "publisher.Publish")
+	r.seen(domain.WithTenant(context.Background(), event.TenantID), "publisher.Publish.event")
+}
+
+func newTenantUseCases(rec *tenantRecorder) []interface{} {
+	userRepo := tenantUserRepo{rec}
+	teamRepo := tenantTeamRepo{rec}
+	prRepo := tenantPullRequestRepo{rec}
+	outboxRepo := tenantOutboxRepo{rec}
+	webhookRepo := tenantWebhookRepo{rec}
+	integrationRepo := tenantIntegrationRepo{rec}
+	exclusionRepo := tenantExclusionRepo{rec}
+	orgUnitRepo := tenantOrgUnitRepo{rec}
+	transactor := tenantTransactor{rec}
+	publisher := tenantPublisher{rec}
+
+	codeOwners := NewCodeOwnersUseCase(tenantCodeOwnersRepo{rec}, userRepo, integrationRepo)
+	prUseCase := NewPullRequestUseCase(prRepo, userRepo, teamRepo, exclusionRepo, orgUnitRepo, tenantSLARepo{rec}, outboxRepo, transactor, publisher, codeOwners, NewRandom(1))
+
+	return []interface{}{
+		codeOwners,
+		prUseCase,
+		NewUserUseCase(userRepo, teamRepo, outboxRepo, transactor, publisher, prUseCase),
+		NewTeamUseCase(teamRepo, userRepo, transactor, prUseCase),
+		NewIntegrationUseCase(integrationRepo, userRepo, prRepo, prUseCase, transactor, nil),
+		NewExclusionUseCase(exclusionRepo, userRepo),
+		NewOrgUnitUseCase(orgUnitRepo, teamRepo, tenantStatsRepo{rec}, transactor),
+		NewWebhookUseCase(webhookRepo, outboxRepo),
+		NewReminderUseCase(userRepo, prRepo),
+	}
+}
+
+var tenantSampleStrings = map[reflect.Type]string{
+	reflect.TypeOf(domain.Seniority("")):        string(domain.SenioritySenior),
+	reflect.TypeOf(domain.TeamRole("")):         string(domain.TeamRoleMember),
+	reflect.TypeOf(domain.ReviewPolicy("")):     string(domain.ReviewPolicyReassign),
+	reflect.TypeOf(domain.CodeOwnersScope("")):  string(domain.CodeOwnersScopeTeam),
+	reflect.TypeOf(domain.CodeHostProvider("")): string(domain.ProviderGitHub),
+	reflect.TypeOf(domain.CodeHostAction("")):   string(domain.CodeHostActionOpened),
+	reflect.TypeOf(domain.EventType("")):        string(domain.EventPRCreated),
+	reflect.TypeOf(domain.PRStatus("")):         string(domain.PRStatusOpen),
+	reflect.TypeOf(domain.ReviewVerdict("")):    string(domain.ReviewVerdictApproved),
+}
+
+var tenantSampleFields = map[string]string{
+	"Email":          "u2@example.com",
+	"URL":            "https://example.com/hook",
+	"WebhookURL":     "https://example.com/hook",
+	"ExcludedUserID": "u3",
+}
+
+func tenantSampleValue(typ reflect.Type, depth int) reflect.Value {
+	value := reflect.New(typ).Elem()
+	if depth > 4 {
+		return value
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		sample, ok := tenantSampleStrings[typ]
+		if !ok {
+			sample = "u2"
+		}
+		value.SetString(sample)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(1)
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Ptr:
+		value.Set(tenantSampleValue(typ.Elem(), depth+1).Addr())
+	case reflect.Slice:
+		value.Set(reflect.Append(value, tenantSampleValue(typ.Elem(), depth+1)))
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if sample, ok := tenantSampleFields[field.Name]; ok && field.Type.Kind() == reflect.String {
+				value.Field(i).SetString(sample)
+				continue
+			}
+			value.Field(i).Set(tenantSampleValue(field.Type, depth+1))
+		}
+	}
+	return value
+}
+
+func callWithTenant(ctx context.Context, method reflect.Value) (results []reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	contextType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	args := make([]reflect.Value, method.Type().NumIn())
+	for i := range args {
+		argType := method.Type().In(i)
+		if argType == contextType {
+			args[i] = reflect.ValueOf(ctx)
+			continue
+		}
+		args[i] = tenantSampleValue(argType, 0)
+	}
+	return method.Call(args), nil
+}
+
+func returnedError(results []reflect.Value) error {
+	if len(results) == 0 {
+		return nil
+	}
+	err, _ := results[len(results)-1].Interface().(error)
+	return err
+}
+
+func TestUseCasesStayWithinTenant(t *testing.T) {
+	rec := &tenantRecorder{}
+	ctx := domain.WithTenant(context.Background(), "t1")
+
+	totalCalls := 0
+	for _, uc := range newTenantUseCases(rec) {
+		ucValue := reflect.ValueOf(uc)
+		ucType := ucValue.Type()
+		for i := 0; i < ucType.NumMethod(); i++ {
+			name := ucType.Elem().Name() + "." + ucType.Method(i).Name
+			rec.reset()
+
+			results, err := callWithTenant(ctx, ucValue.Method(i))
+			require.NoError(t, err, name)
+			if err := returnedError(results); err != nil && len(rec.calls) == 0 {
+				t.Errorf("%s failed before reaching a repository: %v", name, err)
+			}
+			for j, tenant := range rec.tenants {
+				assert.Equal(t, "t1", tenant, "%s reached %s outside the caller's tenant", name, rec.calls[j])
+			}
+			totalCalls += len(rec.tenants)
+		}
+	}
+	assert.Greater(t, totalCalls, 0)
+}
+
+// tenantSeed fills a tenant with rows named after the sample strings, so
+// that every sampled call finds a user, a team and a pull request "u2".
+func tenantSeed(prefix string) *directory {
+	dir := newDirectory(
+		lead("u1", "u2", domain.SenioritySenior),
+		member("u2", "u2", domain.SenioritySenior),
+		member("u3", "u2", domain.SeniorityMiddle),
+		member("u4", "u2", domain.SeniorityMiddle),
+	)
+	for _, user := range dir.users {
+		user.Username = prefix + user.UserID
+	}
+	dir.policies["u2"] = &domain.TeamPolicy{TeamName: "u2", MinSeniorReviewers: 1}
+	dir.prs["u2"] = &domain.PullRequest{PullRequestID: "u2", PullRequestName: prefix + "u2", AuthorID: "u1", ReviewTeam: "u2", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	dir.prs["pr2"] = &domain.PullRequest{PullRequestID: "pr2", PullRequestName: prefix + "pr2", AuthorID: "u3", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}}
+	return dir
+}
+
+func TestUseCasesNeverTouchAnotherTenantsRows(t *testing.T) {
+	ctx := domain.WithTenant(context.Background(), "acme")
+	useCases := func(dir *directory) []interface{} {
+		return []interface{}{newDirectoryUseCase(dir), newDirectoryUserUseCase(dir), newDirectoryTeamUseCase(dir)}
+	}
+
+	touched := 0
+	for k, uc := range useCases(newDirectory()) {
+		ucType := reflect.TypeOf(uc)
+		for i := 0; i < ucType.NumMethod(); i++ {
+			name := ucType.Elem().Name() + "." + ucType.Method(i).Name
+			dir := newDirectory()
+			dir.tenants = map[string]*directory{"acme": tenantSeed("acme-"), "globex": tenantSeed("globex-")}
+
+			results, err := callWithTenant(ctx, reflect.ValueOf(useCases(dir)[k]).Method(i))
+			require.NoError(t, err, name)
+			assert.Equal(t, tenantSeed("globex-"), dir.tenants["globex"], "%s changed another tenant's rows", name)
+
+			returned := make([]interface{}, len(results))
+			for j, result := range results {
+				returned[j] = result.Interface()
+			}
+			data, err := json.Marshal(returned)
+			require.NoError(t, err, name)
+			assert.NotContains(t, string(data), "globex-", "%s returned another tenant's rows", name)
+
+			if strings.Contains(string(data), "acme-") || !reflect.DeepEqual(tenantSeed("acme-"), dir.tenants["acme"]) {
+				touched++
+			}
+		}
+	}
+	assert.Greater(t, touched, 0)
+}
+
+func TestUseCasesDoNotLeakTenantBetweenCalls(t *testing.T) {
+	rec := &tenantRecorder{}
+	useCases := newTenantUseCases(rec)
+	prUseCase := useCases[1].(*PullRequestUseCase)
+
+	for _, tenant := range []string{"t1", "t2"} {
+		rec.reset()
+		_, err := prUseCase.CreatePullRequest(domain.WithTenant(context.Background(), tenant), CreatePullRequestInput{
+			PullRequestID:   "pr1",
+			PullRequestName: "pr1",
+			AuthorID:        "u1",
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, rec.tenants)
+		for j, seen := range rec.tenants {
+			assert.Equal(t, tenant, seen, rec.calls[j])
+		}
+	}
+}
@@ -2,24 +2,366 @@ package usecase
 
 import (
 	"context"
+	"net/mail"
+	"sort"
+	"strings"
 
 	"avitotest/internal/domain"
 )
 
+const (
+	defaultUsersPageSize = 50
+	maxUsersPageSize     = 500
+)
+
+type UpsertUserInput struct {
+	UserID      string
+	Username    string
+	Email       string
+	TeamName    string
+	ChatMention string
+	IsActive    *bool
+	Seniority   domain.Seniority
+	Role        domain.TeamRole
+}
+
 type UserUseCase struct {
-	userRepo domain.UserRepository
+	userRepo   domain.UserRepository
+	teamRepo   domain.TeamRepository
+	transactor domain.Transactor
+	events     *eventRecorder
+	reviews    *PullRequestUseCase
 }
 
-func NewUserUseCase(userRepo domain.UserRepository) *UserUseCase {
+func NewUserUseCase(
+	userRepo domain.UserRepository,
+	teamRepo domain.TeamRepository,
+	outboxRepo domain.OutboxRepository,
+	transactor domain.Transactor,
+	publisher domain.EventPublisher,
+	reviews *PullRequestUseCase,
+) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		transactor: transactor,
+		events:     newEventRecorder(outboxRepo, transactor, publisher),
+		reviews:    reviews,
+	}
+}
+
+func (uc *UserUseCase) UpsertUser(ctx context.Context, input UpsertUserInput) (*domain.User, error) {
+	if input.UserID == "" || input.Username == "" || input.TeamName == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "user_id, username and team_name are required")
+	}
+	if input.Seniority != "" && !input.Seniority.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "seniority must be one of junior, middle, senior")
+	}
+	if input.Role != "" && !input.Role.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "role must be one of member, lead")
+	}
+	if input.Email != "" && !isValidEmail(input.Email) {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "email must be a valid address")
+	}
+	if err := uc.ensureTeamExists(ctx, input.TeamName); err != nil {
+		return nil, err
+	}
+
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uc.userRepo.GetByID(ctx, input.UserID)
+		if err != nil && !hasErrorCode(err, domain.ErrorCodeNotFound) {
+			return err
+		}
+		if existing != nil && existing.ArchivedAt != nil {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "archived user cannot be updated")
+		}
+		if existing != nil && existing.TeamName != input.TeamName {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "use /users/moveTeam to change the team of an existing user")
+		}
+
+		isActive := true
+		if existing != nil {
+			isActive = existing.IsActive
+		}
+		if input.IsActive != nil {
+			isActive = *input.IsActive
+		}
+
+		user := &domain.User{
+			UserID:      input.UserID,
+			Username:    input.Username,
+			Email:       input.Email,
+			TeamName:    input.TeamName,
+			ChatMention: input.ChatMention,
+			IsActive:    isActive,
+			Seniority:   input.Seniority,
+			Role:        input.Role,
+		}
+		if err := uc.userRepo.CreateOrUpdate(ctx, user); err != nil {
+			return err
+		}
+
+		if existing == nil || existing.IsActive == isActive {
+			return nil
+		}
+		payload := domain.UserActivityChangedPayload{UserID: user.UserID, IsActive: isActive}
+		return uc.events.record(ctx, domain.EventUserActivityChanged, user.UserID, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.GetByID(ctx, input.UserID)
+}
+
+func (uc *UserUseCase) ensureTeamExists(ctx context.Context, teamName string) error {
+	exists, err := uc.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return err
 	}
+	if !exists {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return nil
+}
+
+func (uc *UserUseCase) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	return uc.userRepo.GetByID(ctx, userID)
+}
+
+func (uc *UserUseCase) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUsersPageSize
+	}
+	if filter.Limit > maxUsersPageSize {
+		filter.Limit = maxUsersPageSize
+	}
+	if filter.Offset < 0 {
+		return nil, 0, domain.NewDomainError(domain.ErrorCodeValidation, "offset must not be negative")
+	}
+	return uc.userRepo.List(ctx, filter)
+}
+
+func (uc *UserUseCase) MoveTeam(ctx context.Context, userID, teamName string, policy domain.ReviewPolicy) (*domain.User, *domain.ReviewRelease, error) {
+	if teamName == "" {
+		return nil, nil, domain.NewDomainError(domain.ErrorCodeValidation, "team_name is required")
+	}
+	policy, err := reviewPolicyOrDefault(policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.ensureTeamExists(ctx, teamName); err != nil {
+		return nil, nil, err
+	}
+
+	release := domain.NewReviewRelease()
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.ArchivedAt != nil {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "archived user cannot change team")
+		}
+		if user.TeamName == teamName {
+			return nil
+		}
+
+		if user.TeamName != "" {
+			release, err = uc.reviews.ReleaseReviews(ctx, userID, user.TeamName, policy)
+			if err != nil {
+				return err
+			}
+			if err := uc.userRepo.RemoveMembership(ctx, userID, user.TeamName); err != nil {
+				return err
+			}
+		}
+
+		user.TeamName = teamName
+		user.Role = domain.TeamRoleMember
+		return uc.userRepo.CreateOrUpdate(ctx, user)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, release, nil
 }
 
 func (uc *UserUseCase) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
-	if err := uc.userRepo.SetIsActive(ctx, userID, isActive); err != nil {
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if isActive && user.ArchivedAt != nil {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "archived user cannot be activated")
+		}
+		if err := uc.userRepo.SetIsActive(ctx, userID, isActive); err != nil {
+			return err
+		}
+		if user.IsActive == isActive {
+			return nil
+		}
+		payload := domain.UserActivityChangedPayload{UserID: userID, IsActive: isActive}
+		return uc.events.record(ctx, domain.EventUserActivityChanged, userID, payload)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return uc.userRepo.GetByID(ctx, userID)
 }
+
+func (uc *UserUseCase) ArchiveUser(ctx context.Context, userID string, policy domain.ReviewPolicy) (*domain.User, *domain.ReviewRelease, error) {
+	if policy == "" {
+		policy = domain.ReviewPolicyReassign
+	}
+	policy, err := reviewPolicyOrDefault(policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy == domain.ReviewPolicyKeep {
+		return nil, nil, domain.NewDomainError(domain.ErrorCodeValidation, "archived users cannot keep their reviews")
+	}
+
+	var release *domain.ReviewRelease
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.ArchivedAt != nil {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "user is already archived")
+		}
+
+		release, err = uc.reviews.ReleaseReviews(ctx, userID, "", policy)
+		if err != nil {
+			return err
+		}
+		if err := uc.userRepo.Archive(ctx, userID); err != nil {
+			return err
+		}
+
+		if !user.IsActive {
+			return nil
+		}
+		payload := domain.UserActivityChangedPayload{UserID: userID, IsActive: false}
+		return uc.events.record(ctx, domain.EventUserActivityChanged, userID, payload)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, release, nil
+}
+
+func (uc *UserUseCase) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (*domain.User, error) {
+	if !seniority.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "seniority must be one of junior, middle, senior")
+	}
+	if err := uc.userRepo.SetSeniority(ctx, userID, seniority); err != nil {
+		return nil, err
+	}
+	return uc.userRepo.GetByID(ctx, userID)
+}
+
+func (uc *UserUseCase) GetTags(ctx context.Context, userID string) ([]string, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.userRepo.GetTags(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return nonNilTags(tags[userID]), nil
+}
+
+func (uc *UserUseCase) SetTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	return uc.updateTags(ctx, userID, func([]string) []string {
+		return tags
+	})
+}
+
+func (uc *UserUseCase) AddTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	return uc.updateTags(ctx, userID, func(current []string) []string {
+		return append(current, tags...)
+	})
+}
+
+func (uc *UserUseCase) RemoveTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	removed := make(map[string]struct{}, len(tags))
+	for _, tag := range normalizeTags(tags) {
+		removed[tag] = struct{}{}
+	}
+
+	return uc.updateTags(ctx, userID, func(current []string) []string {
+		var kept []string
+		for _, tag := range current {
+			if _, ok := removed[tag]; !ok {
+				kept = append(kept, tag)
+			}
+		}
+		return kept
+	})
+}
+
+func (uc *UserUseCase) updateTags(ctx context.Context, userID string, update func(current []string) []string) ([]string, error) {
+	var result []string
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+			return err
+		}
+
+		current, err := uc.userRepo.GetTags(ctx, []string{userID})
+		if err != nil {
+			return err
+		}
+
+		result = normalizeTags(update(current[userID]))
+		return uc.userRepo.SetTags(ctx, userID, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nonNilTags(result), nil
+}
+
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
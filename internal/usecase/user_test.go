@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"avitotest/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertUserRejectsUnknownTeam(t *testing.T) {
+	dir := newDirectory(member("u1", "backend", domain.SeniorityMiddle))
+	uc := newDirectoryUserUseCase(dir)
+
+	_, err := uc.UpsertUser(context.Background(), UpsertUserInput{UserID: "u2", Username: "u2", TeamName: "ghost"})
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+	assert.NotContains(t, dir.users, "u2")
+
+	user, err := uc.UpsertUser(context.Background(), UpsertUserInput{UserID: "u2", Username: "u2", TeamName: "backend"})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", user.TeamName)
+}
+
+func TestMoveTeamRejectsUnknownTeam(t *testing.T) {
+	dir := newDirectory(member("u1", "backend", domain.SeniorityMiddle))
+	uc := newDirectoryUserUseCase(dir)
+
+	_, _, err := uc.MoveTeam(context.Background(), "u1", "ghost", "")
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+	assert.Equal(t, "backend", dir.users["u1"].TeamName)
+	assert.Equal(t, []string{"backend"}, dir.users["u1"].Teams)
+}
+
+func eventTypes(events []*domain.Event) []domain.EventType {
+	types := make([]domain.EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.EventType)
+	}
+	return types
+}
+
+func TestArchiveUserReleasesReviewsAndHidesUser(t *testing.T) {
+	dir := newDirectory(
+		member("u1", "backend", domain.SeniorityMiddle),
+		member("u2", "backend", domain.SeniorityMiddle),
+		member("author", "frontend", domain.SeniorityMiddle),
+	)
+	dir.users["u1"].Teams = []string{"backend", "frontend"}
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}}
+	uc := newDirectoryUserUseCase(dir)
+
+	user, released, err := uc.ArchiveUser(context.Background(), "u1", domain.ReviewPolicyUnassign)
+	require.NoError(t, err)
+	require.NotNil(t, user.ArchivedAt)
+	assert.False(t, user.IsActive)
+	assert.Equal(t, []string{"pr1"}, released.Released)
+	assert.Empty(t, dir.prs["pr1"].AssignedReviewers)
+	assert.Equal(t, []domain.EventType{domain.EventReviewerUnassigned, domain.EventUserActivityChanged}, eventTypes(dir.events))
+
+	backend, err := uc.userRepo.GetByTeamName(context.Background(), "backend")
+	require.NoError(t, err)
+	require.Len(t, backend, 1)
+	assert.Equal(t, "u2", backend[0].UserID)
+
+	team, err := uc.teamRepo.GetByName(context.Background(), "frontend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 1)
+	assert.Equal(t, "author", team.Members[0].UserID)
+}
+
+func TestArchiveUserHidesLastMembersTeam(t *testing.T) {
+	dir := newDirectory(
+		member("u1", "solo", domain.SeniorityMiddle),
+		member("u2", "backend", domain.SeniorityMiddle),
+	)
+	uc := newDirectoryUserUseCase(dir)
+
+	_, _, err := uc.ArchiveUser(context.Background(), "u1", "")
+	require.NoError(t, err)
+
+	exists, err := uc.teamRepo.Exists(context.Background(), "solo")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, _, err = uc.MoveTeam(context.Background(), "u2", "solo", "")
+	requireErrorCode(t, err, domain.ErrorCodeNotFound)
+}
+
+func TestArchiveUserReassignsReviewsByDefault(t *testing.T) {
+	dir := newDirectory(
+		member("u1", "backend", domain.SeniorityMiddle),
+		member("u2", "backend", domain.SeniorityMiddle),
+		member("author", "backend", domain.SeniorityMiddle),
+	)
+	dir.users["u1"].IsActive = false
+	dir.prs["pr1"] = &domain.PullRequest{PullRequestID: "pr1", AuthorID: "author", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u1"}}
+	uc := newDirectoryUserUseCase(dir)
+
+	_, _, err := uc.ArchiveUser(context.Background(), "u1", domain.ReviewPolicyKeep)
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+	assert.Nil(t, dir.users["u1"].ArchivedAt)
+
+	_, released, err := uc.ArchiveUser(context.Background(), "u1", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr1"}, released.Released)
+	assert.Equal(t, []string{"u2"}, dir.prs["pr1"].AssignedReviewers)
+	assert.Equal(t, []domain.EventType{domain.EventReviewerReassigned}, eventTypes(dir.events), "an inactive user produces no activity change")
+}
+
+func TestArchivedUserCannotBeChanged(t *testing.T) {
+	dir := newDirectory(
+		member("u1", "backend", domain.SeniorityMiddle),
+		member("u2", "backend", domain.SeniorityMiddle),
+		member("f1", "frontend", domain.SeniorityMiddle),
+	)
+	uc := newDirectoryUserUseCase(dir)
+	_, _, err := uc.ArchiveUser(context.Background(), "u1", "")
+	require.NoError(t, err)
+
+	_, _, err = uc.ArchiveUser(context.Background(), "u1", "")
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, err = uc.UpsertUser(context.Background(), UpsertUserInput{UserID: "u1", Username: "u1", TeamName: "backend"})
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, _, err = uc.MoveTeam(context.Background(), "u1", "frontend", "")
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, err = uc.SetIsActive(context.Background(), "u1", true)
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, err = newDirectoryTeamUseCase(dir).AddMembers(context.Background(), "frontend", []domain.TeamMember{{UserID: "u1", Username: "u1"}})
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+
+	_, err = newDirectoryTeamUseCase(dir).CreateTeam(context.Background(), &domain.Team{TeamName: "platform", Members: []domain.TeamMember{{UserID: "u1", Username: "u1"}}})
+	requireErrorCode(t, err, domain.ErrorCodeValidation)
+}
+
+func TestMoveTeamReleasesOnlyReviewsOfTheOldTeam(t *testing.T) {
+	dir := teamDirectory()
+	dir.users["guest"].IsActive = false
+	dir.prs["backend-pr"] = &domain.PullRequest{PullRequestID: "backend-pr", AuthorID: "f1", ReviewTeam: "backend", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+	dir.prs["crowded"] = &domain.PullRequest{PullRequestID: "crowded", AuthorID: "lead", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo", "multi"}}
+	dir.prs["platform-pr"] = &domain.PullRequest{PullRequestID: "platform-pr", AuthorID: "p1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"solo"}}
+	uc := newDirectoryUserUseCase(dir)
+
+	user, release, err := uc.MoveTeam(context.Background(), "solo", "frontend", domain.ReviewPolicyReassign)
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+
+	assert.Equal(t, []string{"backend-pr", "crowded"}, release.Released)
+	assert.Equal(t, []string{"crowded"}, release.Unassigned, "no backend candidate is left for crowded")
+	assert.Equal(t, []string{"lead"}, dir.prs["backend-pr"].AssignedReviewers)
+	assert.Equal(t, []string{"multi"}, dir.prs["crowded"].AssignedReviewers)
+	assert.Equal(t, []string{"solo"}, dir.prs["platform-pr"].AssignedReviewers, "platform reviews do not depend on the backend membership")
+}
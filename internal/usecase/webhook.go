@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+
+	"avitotest/internal/domain"
+)
+
+const deliveriesPageSize = 100
+
+type WebhookUseCase struct {
+	webhookRepo domain.WebhookRepository
+	outboxRepo  domain.OutboxRepository
+}
+
+func NewWebhookUseCase(webhookRepo domain.WebhookRepository, outboxRepo domain.OutboxRepository) *WebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo: webhookRepo,
+		outboxRepo:  outboxRepo,
+	}
+}
+
+func (uc *WebhookUseCase) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	if err := validateSubscription(sub); err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		return nil, domain.NewDomainError(domain.ErrorCodeValidation, "secret is required")
+	}
+
+	sub.IsActive = true
+	if err := uc.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (uc *WebhookUseCase) GetSubscription(ctx context.Context, subscriptionID int64) (*domain.WebhookSubscription, error) {
+	return uc.webhookRepo.GetSubscription(ctx, subscriptionID)
+}
+
+func (uc *WebhookUseCase) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subs, err := uc.webhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if subs == nil {
+		subs = []*domain.WebhookSubscription{}
+	}
+	return subs, nil
+}
+
+func (uc *WebhookUseCase) UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	if err := validateSubscription(sub); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.webhookRepo.GetSubscription(ctx, sub.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		sub.Secret = existing.Secret
+	}
+	sub.CreatedAt = existing.CreatedAt
+
+	if err := uc.webhookRepo.UpdateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (uc *WebhookUseCase) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	return uc.webhookRepo.DeleteSubscription(ctx, subscriptionID)
+}
+
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, subscriptionID int64) ([]*domain.WebhookDelivery, error) {
+	if _, err := uc.webhookRepo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := uc.webhookRepo.ListDeliveries(ctx, subscriptionID, deliveriesPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryIDs := make([]int64, 0, len(deliveries))
+	byID := make(map[int64]*domain.WebhookDelivery, len(deliveries))
+	for _, delivery := range deliveries {
+		delivery.AttemptLog = []*domain.DeliveryAttempt{}
+		deliveryIDs = append(deliveryIDs, delivery.DeliveryID)
+		byID[delivery.DeliveryID] = delivery
+	}
+
+	attempts, err := uc.webhookRepo.ListAttempts(ctx, deliveryIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, attempt := range attempts {
+		if delivery, ok := byID[attempt.DeliveryID]; ok {
+			delivery.AttemptLog = append(delivery.AttemptLog, attempt)
+		}
+	}
+
+	if deliveries == nil {
+		deliveries = []*domain.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+func (uc *WebhookUseCase) Redeliver(ctx context.Context, subscriptionID, eventID int64) (*domain.WebhookDelivery, error) {
+	if _, err := uc.webhookRepo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	event, err := uc.outboxRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := uc.webhookRepo.CreateDelivery(ctx, subscriptionID, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	delivery.EventType = event.EventType
+	delivery.AttemptLog = []*domain.DeliveryAttempt{}
+	return delivery, nil
+}
+
+func validateSubscription(sub *domain.WebhookSubscription) error {
+	parsed, err := url.Parse(sub.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return domain.NewDomainError(domain.ErrorCodeValidation, "url must be an absolute http(s) URL")
+	}
+	for _, eventType := range sub.EventTypes {
+		if !eventType.IsValid() {
+			return domain.NewDomainError(domain.ErrorCodeValidation, "unknown event type: "+string(eventType))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"avitotest/internal/domain"
+)
+
+type DispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+type Dispatcher struct {
+	outboxRepo  domain.OutboxRepository
+	webhookRepo domain.WebhookRepository
+	transactor  domain.Transactor
+	sender      *Sender
+	fanOuts     []func(ctx context.Context, event *domain.Event) error
+	cfg         DispatcherConfig
+	logger      *slog.Logger
+}
+
+func NewDispatcher(
+	outboxRepo domain.OutboxRepository,
+	webhookRepo domain.WebhookRepository,
+	transactor domain.Transactor,
+	sender *Sender,
+	cfg DispatcherConfig,
+	logger *slog.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		outboxRepo:  outboxRepo,
+		webhookRepo: webhookRepo,
+		transactor:  transactor,
+		sender:      sender,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (d *Dispatcher) AddFanOut(fn func(ctx context.Context, event *domain.Event) error) {
+	d.fanOuts = append(d.fanOuts, fn)
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DispatchBatch(ctx); err != nil {
+				d.logger.Error("outbox dispatch failed", "error", err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) DispatchBatch(ctx context.Context) error {
+	if err := d.fanOut(ctx); err != nil {
+		return err
+	}
+	return d.deliverDue(ctx)
+}
+
+func (d *Dispatcher) lease() time.Duration {
+	return d.cfg.PollInterval + d.cfg.MaxBackoff
+}
+
+func (d *Dispatcher) fanOut(ctx context.Context) error {
+	entries, err := d.outboxRepo.ClaimDue(ctx, d.cfg.BatchSize, d.lease())
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	subsByTenant := map[string][]*domain.WebhookSubscription{}
+	for _, entry := range entries {
+		ctx := domain.WithTenant(ctx, entry.TenantID)
+		subs, ok := subsByTenant[entry.TenantID]
+		if !ok {
+			subs, err = d.webhookRepo.ListSubscriptions(ctx)
+			if err != nil {
+				return err
+			}
+			subsByTenant[entry.TenantID] = subs
+		}
+
+		err := d.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			for _, sub := range subs {
+				if !sub.IsActive || !sub.Matches(entry.EventType) {
+					continue
+				}
+				if _, err := d.webhookRepo.CreateDelivery(ctx, sub.SubscriptionID, entry.EventID); err != nil {
+					return err
+				}
+			}
+			for _, fanOut := range d.fanOuts {
+				if err := fanOut(ctx, &entry.Event); err != nil {
+					return err
+				}
+			}
+			return d.outboxRepo.MarkDelivered(ctx, entry.EventID)
+		})
+		if err != nil {
+			attempts := entry.Attempts + 1
+			dead := attempts >= d.cfg.MaxAttempts
+			d.logger.Error("failed to fan out outbox event", "event_id", entry.EventID, "attempts", attempts, "error", err)
+			if err := d.outboxRepo.MarkFailed(ctx, entry.EventID, err.Error(), time.Now().Add(d.backoff(attempts)), dead); err != nil {
+				d.logger.Error("failed to mark event failed", "event_id", entry.EventID, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliverDue(ctx context.Context) error {
+	tasks, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.cfg.BatchSize, d.lease())
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		ctx := domain.WithTenant(ctx, task.Event.TenantID)
+		start := time.Now()
+		statusCode, deliveryErr := d.sender.Send(ctx, task.URL, task.Secret, task.DeliveryID, &task.Event)
+
+		attempt := &domain.DeliveryAttempt{
+			DeliveryID:  task.DeliveryID,
+			AttemptedAt: start,
+			StatusCode:  statusCode,
+			DurationMs:  time.Since(start).Milliseconds(),
+		}
+		if deliveryErr != nil {
+			attempt.Error = deliveryErr.Error()
+		}
+		if err := d.webhookRepo.RecordAttempt(ctx, attempt); err != nil {
+			d.logger.Error("failed to record delivery attempt", "delivery_id", task.DeliveryID, "error", err)
+		}
+
+		if deliveryErr != nil {
+			d.fail(ctx, task, deliveryErr)
+			continue
+		}
+		if err := d.webhookRepo.MarkDeliveryDelivered(ctx, task.DeliveryID); err != nil {
+			d.logger.Error("failed to mark delivery delivered", "delivery_id", task.DeliveryID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) fail(ctx context.Context, task *domain.WebhookDeliveryTask, deliveryErr error) {
+	attempts := task.Attempts + 1
+	dead := attempts >= d.cfg.MaxAttempts
+	nextAttemptAt := time.Now().Add(d.backoff(attempts))
+
+	logArgs := []interface{}{
+		"delivery_id", task.DeliveryID,
+		"subscription_id", task.SubscriptionID,
+		"event_id", task.Event.EventID,
+		"event_type", task.Event.EventType,
+		"attempts", attempts,
+		"error", deliveryErr,
+	}
+	if dead {
+		d.logger.Error("webhook delivery moved to dead-letter", logArgs...)
+	} else {
+		d.logger.Warn("webhook delivery failed", logArgs...)
+	}
+
+	if err := d.webhookRepo.MarkDeliveryFailed(ctx, task.DeliveryID, deliveryErr.Error(), nextAttemptAt, dead); err != nil {
+		d.logger.Error("failed to mark delivery failed", "delivery_id", task.DeliveryID, "error", err)
+	}
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,295 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failedMark struct {
+	id            int64
+	lastError     string
+	nextAttemptAt time.Time
+	dead          bool
+}
+
+type fakeOutboxRepo struct {
+	domain.OutboxRepository
+
+	mu        sync.Mutex
+	entries   []*domain.OutboxEntry
+	delivered []int64
+	failed    []failedMark
+}
+
+func (r *fakeOutboxRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := r.entries
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	r.entries = r.entries[len(entries):]
+	return entries, nil
+}
+
+func (r *fakeOutboxRepo) MarkDelivered(ctx context.Context, eventID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delivered = append(r.delivered, eventID)
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkFailed(ctx context.Context, eventID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed = append(r.failed, failedMark{eventID, lastError, nextAttemptAt, dead})
+	return nil
+}
+
+type createdDelivery struct {
+	tenantID       string
+	subscriptionID int64
+	eventID        int64
+}
+
+type fakeWebhookRepo struct {
+	domain.WebhookRepository
+
+	mu            sync.Mutex
+	subs          map[string][]*domain.WebhookSubscription
+	listedTenants []string
+	createErr     error
+	created       []createdDelivery
+	tasks         []*domain.WebhookDeliveryTask
+	attempts      []*domain.DeliveryAttempt
+	delivered     []int64
+	failed        []failedMark
+}
+
+func (r *fakeWebhookRepo) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tenantID := domain.TenantFromContext(ctx)
+	r.listedTenants = append(r.listedTenants, tenantID)
+	return r.subs[tenantID], nil
+}
+
+func (r *fakeWebhookRepo) CreateDelivery(ctx context.Context, subscriptionID, eventID int64) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	r.created = append(r.created, createdDelivery{domain.TenantFromContext(ctx), subscriptionID, eventID})
+	return &domain.WebhookDelivery{SubscriptionID: subscriptionID, EventID: eventID}, nil
+}
+
+func (r *fakeWebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDeliveryTask, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := r.tasks
+	r.tasks = nil
+	return tasks, nil
+}
+
+func (r *fakeWebhookRepo) RecordAttempt(ctx context.Context, attempt *domain.DeliveryAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *fakeWebhookRepo) MarkDeliveryDelivered(ctx context.Context, deliveryID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delivered = append(r.delivered, deliveryID)
+	return nil
+}
+
+func (r *fakeWebhookRepo) MarkDeliveryFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failed = append(r.failed, failedMark{deliveryID, lastError, nextAttemptAt, dead})
+	return nil
+}
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (fakeTransactor) AfterCommit(ctx context.Context, fn func()) {
+	fn()
+}
+
+var dispatcherConfig = webhook.DispatcherConfig{
+	PollInterval: time.Second,
+	BatchSize:    10,
+	MaxAttempts:  3,
+	BaseBackoff:  time.Second,
+	MaxBackoff:   3 * time.Second,
+}
+
+func newTestDispatcher(outboxRepo *fakeOutboxRepo, webhookRepo *fakeWebhookRepo) *webhook.Dispatcher {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return webhook.NewDispatcher(outboxRepo, webhookRepo, fakeTransactor{}, webhook.NewSender(time.Second), dispatcherConfig, logger)
+}
+
+func outboxEntry(eventID int64, tenantID string, eventType domain.EventType, attempts int) *domain.OutboxEntry {
+	return &domain.OutboxEntry{
+		Event:    domain.Event{EventID: eventID, TenantID: tenantID, EventType: eventType},
+		Attempts: attempts,
+	}
+}
+
+func assertBackoff(t *testing.T, want time.Duration, mark failedMark, before time.Time) {
+	t.Helper()
+	delay := mark.nextAttemptAt.Sub(before)
+	assert.GreaterOrEqual(t, delay, want)
+	assert.Less(t, delay, want+time.Second)
+}
+
+func TestDispatcherFansOutToMatchingSubscriptionsPerTenant(t *testing.T) {
+	outboxRepo := &fakeOutboxRepo{entries: []*domain.OutboxEntry{
+		outboxEntry(1, "t1", domain.EventPRCreated, 0),
+		outboxEntry(2, "t1", domain.EventPRMerged, 0),
+		outboxEntry(3, "t2", domain.EventPRCreated, 0),
+	}}
+	webhookRepo := &fakeWebhookRepo{subs: map[string][]*domain.WebhookSubscription{
+		"t1": {
+			{SubscriptionID: 10, IsActive: true},
+			{SubscriptionID: 11, IsActive: true, EventTypes: []domain.EventType{domain.EventPRMerged}},
+			{SubscriptionID: 12, IsActive: false},
+		},
+		"t2": {
+			{SubscriptionID: 20, IsActive: true, EventTypes: []domain.EventType{domain.EventPRCreated}},
+		},
+	}}
+
+	require.NoError(t, newTestDispatcher(outboxRepo, webhookRepo).DispatchBatch(context.Background()))
+
+	assert.Equal(t, []createdDelivery{
+		{"t1", 10, 1},
+		{"t1", 10, 2},
+		{"t1", 11, 2},
+		{"t2", 20, 3},
+	}, webhookRepo.created)
+	assert.Equal(t, []string{"t1", "t2"}, webhookRepo.listedTenants)
+	assert.Equal(t, []int64{1, 2, 3}, outboxRepo.delivered)
+	assert.Empty(t, outboxRepo.failed)
+}
+
+func TestDispatcherRetriesFailedFanOutWithBackoff(t *testing.T) {
+	outboxRepo := &fakeOutboxRepo{entries: []*domain.OutboxEntry{
+		outboxEntry(1, "t1", domain.EventPRCreated, 0),
+		outboxEntry(2, "t1", domain.EventPRCreated, 1),
+		outboxEntry(3, "t1", domain.EventPRCreated, 2),
+	}}
+	webhookRepo := &fakeWebhookRepo{
+		subs:      map[string][]*domain.WebhookSubscription{"t1": {{SubscriptionID: 10, IsActive: true}}},
+		createErr: errors.New("db down"),
+	}
+
+	before := time.Now()
+	require.NoError(t, newTestDispatcher(outboxRepo, webhookRepo).DispatchBatch(context.Background()))
+
+	assert.Empty(t, outboxRepo.delivered)
+	require.Len(t, outboxRepo.failed, 3)
+	for i, want := range []struct {
+		id      int64
+		backoff time.Duration
+		dead    bool
+	}{
+		{1, time.Second, false},
+		{2, 2 * time.Second, false},
+		{3, 3 * time.Second, true},
+	} {
+		mark := outboxRepo.failed[i]
+		assert.Equal(t, want.id, mark.id)
+		assert.Equal(t, "db down", mark.lastError)
+		assert.Equal(t, want.dead, mark.dead)
+		assertBackoff(t, want.backoff, mark, before)
+	}
+}
+
+func TestDispatcherDeliversDueTasks(t *testing.T) {
+	var received []string
+	var mu sync.Mutex
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Header.Get("X-Delivery-ID"))
+		mu.Unlock()
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	event := domain.Event{EventID: 1, TenantID: "t1", EventType: domain.EventPRCreated, Payload: []byte(`{}`)}
+	webhookRepo := &fakeWebhookRepo{tasks: []*domain.WebhookDeliveryTask{
+		{DeliveryID: 100, SubscriptionID: 10, URL: receiver.URL + "/ok", Event: event},
+		{DeliveryID: 101, SubscriptionID: 11, URL: receiver.URL + "/broken", Attempts: 1, Event: event},
+		{DeliveryID: 102, SubscriptionID: 12, URL: receiver.URL + "/broken", Attempts: 2, Event: event},
+	}}
+
+	before := time.Now()
+	require.NoError(t, newTestDispatcher(&fakeOutboxRepo{}, webhookRepo).DispatchBatch(context.Background()))
+
+	assert.Equal(t, []string{"100", "101", "102"}, received)
+	assert.Equal(t, []int64{100}, webhookRepo.delivered)
+
+	require.Len(t, webhookRepo.attempts, 3)
+	assert.Equal(t, http.StatusOK, webhookRepo.attempts[0].StatusCode)
+	assert.Empty(t, webhookRepo.attempts[0].Error)
+	assert.Equal(t, http.StatusInternalServerError, webhookRepo.attempts[1].StatusCode)
+	assert.Contains(t, webhookRepo.attempts[1].Error, "status 500")
+
+	require.Len(t, webhookRepo.failed, 2)
+	assert.Equal(t, int64(101), webhookRepo.failed[0].id)
+	assert.False(t, webhookRepo.failed[0].dead)
+	assertBackoff(t, 2*time.Second, webhookRepo.failed[0], before)
+	assert.Equal(t, int64(102), webhookRepo.failed[1].id)
+	assert.True(t, webhookRepo.failed[1].dead)
+	assertBackoff(t, 3*time.Second, webhookRepo.failed[1], before)
+}
+
+func TestDispatcherRunsExtraFanOutsWithEvent(t *testing.T) {
+	outboxRepo := &fakeOutboxRepo{entries: []*domain.OutboxEntry{
+		outboxEntry(1, "t1", domain.EventReviewerAssigned, 0),
+		outboxEntry(2, "t2", domain.EventReviewerAssigned, 0),
+	}}
+	dispatcher := newTestDispatcher(outboxRepo, &fakeWebhookRepo{})
+
+	var seen []string
+	dispatcher.AddFanOut(func(ctx context.Context, event *domain.Event) error {
+		seen = append(seen, domain.TenantFromContext(ctx))
+		if event.EventID == 2 {
+			return errors.New("sync job insert failed")
+		}
+		return nil
+	})
+
+	require.NoError(t, dispatcher.DispatchBatch(context.Background()))
+
+	assert.Equal(t, []string{"t1", "t2"}, seen)
+	assert.Equal(t, []int64{1}, outboxRepo.delivered)
+	require.Len(t, outboxRepo.failed, 1)
+	assert.Equal(t, int64(2), outboxRepo.failed[0].id)
+	assert.Equal(t, "sync job insert failed", outboxRepo.failed[0].lastError)
+}
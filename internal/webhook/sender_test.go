@@ -0,0 +1,77 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitotest/internal/domain"
+	"avitotest/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderSignsPayload(t *testing.T) {
+	const secret = "s3cret"
+
+	var received domain.Event
+	var signatureValid bool
+	var headers http.Header
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		headers = r.Header.Clone()
+		signatureValid = r.Header.Get(webhook.SignatureHeader) == webhook.Sign(secret, body)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	event, err := domain.NewEvent(domain.EventReviewerAssigned, "pr-1", domain.ReviewerAssignedPayload{
+		PullRequestID: "pr-1",
+		ReviewerID:    "u2",
+	})
+	require.NoError(t, err)
+	event.EventID = 42
+
+	sender := webhook.NewSender(time.Second)
+	status, err := sender.Send(context.Background(), receiver.URL, secret, 7, event)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.True(t, signatureValid)
+	assert.Equal(t, "ReviewerAssigned", headers.Get("X-Event-Type"))
+	assert.Equal(t, "42", headers.Get("X-Event-ID"))
+	assert.Equal(t, "7", headers.Get("X-Delivery-ID"))
+	assert.Equal(t, int64(42), received.EventID)
+	assert.Equal(t, domain.EventReviewerAssigned, received.EventType)
+	assert.JSONEq(t, `{"pull_request_id":"pr-1","reviewer_id":"u2"}`, string(received.Payload))
+}
+
+func TestSenderReportsNon2xx(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	event, err := domain.NewEvent(domain.EventPRMerged, "pr-1", domain.PRMergedPayload{})
+	require.NoError(t, err)
+
+	sender := webhook.NewSender(time.Second)
+	status, err := sender.Send(context.Background(), receiver.URL, "secret", 1, event)
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestSignIsStable(t *testing.T) {
+	assert.Equal(t,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		webhook.Sign("key", []byte("The quick brown fox jumps over the lazy dog")),
+	)
+}
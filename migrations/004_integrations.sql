@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS external_identities (
+    provider VARCHAR(50) NOT NULL,
+    external_login VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, external_login),
+    CONSTRAINT fk_identity_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_identities_user_id ON external_identities(user_id);
+
+CREATE TABLE IF NOT EXISTS external_pull_requests (
+    pull_request_id VARCHAR(255) PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    repository VARCHAR(255) NOT NULL,
+    number INTEGER NOT NULL,
+    CONSTRAINT uq_external_pr UNIQUE (provider, repository, number),
+    CONSTRAINT fk_external_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
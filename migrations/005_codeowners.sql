@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS codeowners_rulesets (
+    scope VARCHAR(50) NOT NULL,
+    scope_name VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, scope_name)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_tags (
+    user_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(100) NOT NULL,
+    PRIMARY KEY (user_id, tag),
+    CONSTRAINT fk_tag_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tags_tag ON user_tags(tag);
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '[]';
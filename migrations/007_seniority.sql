@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seniority VARCHAR(20) NOT NULL DEFAULT 'middle';
+
+CREATE TABLE IF NOT EXISTS team_policies (
+    team_name VARCHAR(255) PRIMARY KEY,
+    min_senior_reviewers INT NOT NULL DEFAULT 0 CHECK (min_senior_reviewers >= 0)
+);
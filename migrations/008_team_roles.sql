@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member';
+
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS lead_review_labels JSONB NOT NULL DEFAULT '[]';
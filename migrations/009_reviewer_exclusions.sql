@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS reviewer_exclusions (
+    exclusion_id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    excluded_user_id VARCHAR(255) NOT NULL,
+    symmetric BOOLEAN NOT NULL DEFAULT TRUE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_reviewer_exclusion UNIQUE (user_id, excluded_user_id),
+    CONSTRAINT chk_exclusion_distinct CHECK (user_id <> excluded_user_id),
+    CONSTRAINT fk_exclusion_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_exclusion_excluded_user FOREIGN KEY (excluded_user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_exclusions_excluded ON reviewer_exclusions(excluded_user_id);
@@ -0,0 +1 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS co_author_ids JSONB NOT NULL DEFAULT '[]';
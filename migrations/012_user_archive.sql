@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS fk_author;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT fk_author FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE RESTRICT;
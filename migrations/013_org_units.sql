@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS org_units (
+    unit_id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    parent_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_org_unit_parent FOREIGN KEY (parent_id) REFERENCES org_units(unit_id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_units_parent ON org_units(parent_id);
+
+CREATE TABLE IF NOT EXISTS org_unit_teams (
+    team_name VARCHAR(255) PRIMARY KEY,
+    unit_id VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_org_unit_team_unit FOREIGN KEY (unit_id) REFERENCES org_units(unit_id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_unit_teams_unit ON org_unit_teams(unit_id);
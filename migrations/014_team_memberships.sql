@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS team_memberships (
+    user_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    joined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, team_name),
+    CONSTRAINT fk_team_membership_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_name ON team_memberships(team_name);
+
+INSERT INTO team_memberships (user_id, team_name)
+SELECT user_id, team_name FROM users WHERE team_name <> ''
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS review_team VARCHAR(255) NOT NULL DEFAULT '';
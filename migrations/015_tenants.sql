@@ -0,0 +1,79 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_delivery_attempts ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE external_identities ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE external_pull_requests ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE codeowners_rulesets ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE user_tags ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE reviewer_exclusions ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE org_units ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE org_unit_teams ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE team_memberships ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS fk_author;
+ALTER TABLE external_identities DROP CONSTRAINT IF EXISTS fk_identity_user;
+ALTER TABLE external_pull_requests DROP CONSTRAINT IF EXISTS fk_external_pr;
+ALTER TABLE external_pull_requests DROP CONSTRAINT IF EXISTS uq_external_pr;
+ALTER TABLE user_tags DROP CONSTRAINT IF EXISTS fk_tag_user;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS fk_exclusion_user;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS fk_exclusion_excluded_user;
+ALTER TABLE reviewer_exclusions DROP CONSTRAINT IF EXISTS uq_reviewer_exclusion;
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS fk_team_membership_user;
+ALTER TABLE org_units DROP CONSTRAINT IF EXISTS fk_org_unit_parent;
+ALTER TABLE org_unit_teams DROP CONSTRAINT IF EXISTS fk_org_unit_team_unit;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE users ADD PRIMARY KEY (tenant_id, user_id);
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE pull_requests ADD PRIMARY KEY (tenant_id, pull_request_id);
+ALTER TABLE external_identities DROP CONSTRAINT IF EXISTS external_identities_pkey;
+ALTER TABLE external_identities ADD PRIMARY KEY (tenant_id, provider, external_login);
+ALTER TABLE external_pull_requests DROP CONSTRAINT IF EXISTS external_pull_requests_pkey;
+ALTER TABLE external_pull_requests ADD PRIMARY KEY (tenant_id, pull_request_id);
+ALTER TABLE codeowners_rulesets DROP CONSTRAINT IF EXISTS codeowners_rulesets_pkey;
+ALTER TABLE codeowners_rulesets ADD PRIMARY KEY (tenant_id, scope, scope_name);
+ALTER TABLE user_tags DROP CONSTRAINT IF EXISTS user_tags_pkey;
+ALTER TABLE user_tags ADD PRIMARY KEY (tenant_id, user_id, tag);
+ALTER TABLE team_policies DROP CONSTRAINT IF EXISTS team_policies_pkey;
+ALTER TABLE team_policies ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE org_units DROP CONSTRAINT IF EXISTS org_units_pkey;
+ALTER TABLE org_units ADD PRIMARY KEY (tenant_id, unit_id);
+ALTER TABLE org_unit_teams DROP CONSTRAINT IF EXISTS org_unit_teams_pkey;
+ALTER TABLE org_unit_teams ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_pkey;
+ALTER TABLE team_memberships ADD PRIMARY KEY (tenant_id, user_id, team_name);
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT fk_author FOREIGN KEY (tenant_id, author_id) REFERENCES users(tenant_id, user_id) ON DELETE RESTRICT;
+ALTER TABLE external_identities
+    ADD CONSTRAINT fk_identity_user FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE external_pull_requests
+    ADD CONSTRAINT uq_external_pr UNIQUE (tenant_id, provider, repository, number);
+ALTER TABLE external_pull_requests
+    ADD CONSTRAINT fk_external_pr FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, pull_request_id) ON DELETE CASCADE;
+ALTER TABLE user_tags
+    ADD CONSTRAINT fk_tag_user FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusions
+    ADD CONSTRAINT uq_reviewer_exclusion UNIQUE (tenant_id, user_id, excluded_user_id);
+ALTER TABLE reviewer_exclusions
+    ADD CONSTRAINT fk_exclusion_user FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusions
+    ADD CONSTRAINT fk_exclusion_excluded_user FOREIGN KEY (tenant_id, excluded_user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE team_memberships
+    ADD CONSTRAINT fk_team_membership_user FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE org_units
+    ADD CONSTRAINT fk_org_unit_parent FOREIGN KEY (tenant_id, parent_id) REFERENCES org_units(tenant_id, unit_id) ON DELETE RESTRICT;
+ALTER TABLE org_unit_teams
+    ADD CONSTRAINT fk_org_unit_team_unit FOREIGN KEY (tenant_id, unit_id) REFERENCES org_units(tenant_id, unit_id) ON DELETE RESTRICT;
+
+DROP INDEX IF EXISTS idx_users_team_name;
+CREATE INDEX IF NOT EXISTS idx_users_tenant_team_name ON users(tenant_id, team_name);
+DROP INDEX IF EXISTS idx_team_memberships_team_name;
+CREATE INDEX IF NOT EXISTS idx_team_memberships_tenant_team_name ON team_memberships(tenant_id, team_name);
+CREATE INDEX IF NOT EXISTS idx_pr_tenant_status ON pull_requests(tenant_id, status);
+CREATE INDEX IF NOT EXISTS idx_outbox_tenant ON outbox_events(tenant_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant ON webhook_subscriptions(tenant_id);
@@ -0,0 +1,20 @@
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS first_verdict_sla_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS merge_sla_seconds INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS first_verdict_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS sla_breaches (
+    breach_id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    pull_request_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    sla_seconds INTEGER NOT NULL,
+    breached_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    backup_reviewer_id VARCHAR(255),
+    CONSTRAINT uq_sla_breach UNIQUE (tenant_id, pull_request_id, kind),
+    CONSTRAINT fk_sla_breach_pr FOREIGN KEY (tenant_id, pull_request_id)
+        REFERENCES pull_requests(tenant_id, pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sla_breaches_tenant_team ON sla_breaches(tenant_id, team_name);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS team_chat_channels (
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    team_name VARCHAR(255) NOT NULL,
+    webhook_url TEXT NOT NULL,
+    channel VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (tenant_id, team_name)
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS chat_mention VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS codehost_sync_jobs (
+    event_id BIGINT PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    synced_at TIMESTAMP,
+    CONSTRAINT fk_sync_job_event FOREIGN KEY (event_id) REFERENCES outbox_events(event_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_codehost_sync_jobs_due ON codehost_sync_jobs(next_attempt_at) WHERE status = 'PENDING';
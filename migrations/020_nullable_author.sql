@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests ALTER COLUMN author_id DROP NOT NULL;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS fk_author;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT fk_author FOREIGN KEY (tenant_id, author_id) REFERENCES users(tenant_id, user_id) ON DELETE SET NULL (author_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS review_verdicts (
+    verdict_id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    pull_request_id VARCHAR(255) NOT NULL,
+    reviewer_id VARCHAR(255) NOT NULL,
+    verdict VARCHAR(32) NOT NULL,
+    submitted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_review_verdict_pr FOREIGN KEY (tenant_id, pull_request_id)
+        REFERENCES pull_requests(tenant_id, pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_verdicts_tenant_pr ON review_verdicts(tenant_id, pull_request_id);
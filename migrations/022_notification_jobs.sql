@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS notification_jobs (
+    job_id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    channel VARCHAR(50) NOT NULL,
+    recipient VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP,
+    CONSTRAINT uq_notification_job UNIQUE (event_id, channel, recipient),
+    CONSTRAINT fk_notification_job_event FOREIGN KEY (event_id) REFERENCES outbox_events(event_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_jobs_due ON notification_jobs(channel, next_attempt_at) WHERE status = 'PENDING';